@@ -0,0 +1,156 @@
+package cvc
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/MyNextID/cvc-go/pkg"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// Algorithm identifies the asymmetric algorithm DeriveSecretKeyWithAlgorithm
+// derives a child key for. Unlike internal.Curve, which only names the
+// Weierstrass curves backing KeyMaterial, Algorithm also covers Ed25519,
+// whose derivation doesn't go through scalar/point arithmetic at all.
+type Algorithm int
+
+const (
+	AlgorithmP256 Algorithm = iota
+	AlgorithmP384
+	AlgorithmSecp256k1
+	AlgorithmEd25519
+)
+
+// String returns the algorithm's canonical JOSE curve/algorithm name.
+func (a Algorithm) String() string {
+	switch a {
+	case AlgorithmP256:
+		return "P-256"
+	case AlgorithmP384:
+		return "P-384"
+	case AlgorithmSecp256k1:
+		return "secp256k1"
+	case AlgorithmEd25519:
+		return "Ed25519"
+	default:
+		return "unknown algorithm"
+	}
+}
+
+// curveForAlgorithm maps the Weierstrass algorithms to their internal.Curve.
+// AlgorithmEd25519 has no internal.Curve counterpart and reports false.
+func curveForAlgorithm(a Algorithm) (internal.Curve, bool) {
+	switch a {
+	case AlgorithmP256:
+		return internal.CurveP256, true
+	case AlgorithmP384:
+		return internal.CurveP384, true
+	case AlgorithmSecp256k1:
+		return internal.CurveSecp256k1, true
+	default:
+		return 0, false
+	}
+}
+
+// GenerateSecretKeyWithAlgorithm generates a fresh secret key for alg: the
+// appropriate internal.Curve for the Weierstrass algorithms, or a plain
+// Ed25519 key pair for AlgorithmEd25519. It's the algorithm-agile
+// counterpart to GenerateSecretKey, which always produces a P-256 key.
+func GenerateSecretKeyWithAlgorithm(alg Algorithm, opts ...KeyOption) (jwk.Key, error) {
+	if alg == AlgorithmEd25519 {
+		key, err := GenerateEd25519Key()
+		if err != nil {
+			return nil, err
+		}
+		return applyKeyOptions(key, opts)
+	}
+
+	curve, ok := curveForAlgorithm(alg)
+	if !ok {
+		return nil, internal.WrapError(internal.ErrCurveUnsupported, fmt.Sprintf("algorithm %s is not supported by GenerateSecretKeyWithAlgorithm", alg))
+	}
+
+	key, err := GenerateSecretKeyForCurve(curve)
+	if err != nil {
+		return nil, err
+	}
+	return applyKeyOptions(key, opts)
+}
+
+// DeriveSecretKeyWithAlgorithm derives a child key under master using the
+// hash-to-field/hash-to-scalar step appropriate for alg: the same RFC 9380
+// hash-to-field path DeriveSecretKey already uses for the NIST curves and
+// secp256k1, or RFC 8032 seed clamping for Ed25519, which can't flow
+// through KeyMaterial the way the Weierstrass curves do.
+//
+// alg must match master's own key type and curve: master's scalar is always
+// reduced modulo its own curve order (or, for Ed25519, used as raw seed
+// material for its own key space), so deriving a P-384 child from a P-256
+// master isn't meaningful. A mismatched alg returns an error rather than
+// silently deriving under the wrong curve.
+//
+// DeriveSecretKeyWithAlgorithm(master, context, dst, AlgorithmP256, opts...)
+// is a compatibility shim: it calls DeriveSecretKey directly, so existing
+// P-256 callers keep byte-for-byte identical behavior.
+func DeriveSecretKeyWithAlgorithm(master jwk.Key, context, dst []byte, alg Algorithm, opts ...KeyOption) (jwk.Key, error) {
+	if master == nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, "master key cannot be nil")
+	}
+
+	if alg == AlgorithmEd25519 {
+		return deriveEd25519SecretKey(master, context, dst, opts...)
+	}
+
+	curve, ok := curveForAlgorithm(alg)
+	if !ok {
+		return nil, internal.WrapError(internal.ErrCurveUnsupported, fmt.Sprintf("algorithm %s is not supported by DeriveSecretKeyWithAlgorithm", alg))
+	}
+
+	masterPrivateKey, err := extractPrivateKey(master, "master key")
+	if err != nil {
+		return nil, err
+	}
+
+	masterCurve, err := curveFromEllipticCurve(masterPrivateKey.Curve)
+	if err != nil {
+		return nil, err
+	}
+	if masterCurve != curve {
+		return nil, internal.WrapError(internal.ErrCurveUnsupported, fmt.Sprintf("master key is on %s, not the requested %s", masterCurve, curve))
+	}
+
+	return DeriveSecretKey(master, context, dst, opts...)
+}
+
+// deriveEd25519SecretKey is the Ed25519 branch of DeriveSecretKeyWithAlgorithm.
+// It hashes the master key and context into a 32-byte seed and expands it
+// into an Ed25519 key pair via ed25519.NewKeyFromSeed, which performs the
+// RFC 8032 clamping step itself - there's no separate public-key point to
+// validate the way pkg.ValidatePublicKey does for the Weierstrass curves,
+// since every 32-byte seed yields a valid Ed25519 key pair.
+func deriveEd25519SecretKey(master jwk.Key, context, dst []byte, opts ...KeyOption) (jwk.Key, error) {
+	var masterKey ed25519.PrivateKey
+	if err := master.Raw(&masterKey); err != nil {
+		return nil, internal.WrapError(internal.ErrJWKExtraction, "master key is not an Ed25519 private key")
+	}
+
+	masterBytes, err := pkg.JWKToJson(master)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrJWKExtraction, "failed to convert master key to JSON")
+	}
+
+	derivedSeed, err := internal.DeriveEd25519Seed(masterBytes, context, dst)
+	if err != nil {
+		return nil, internal.WrapError(err, "Ed25519 key derivation failed")
+	}
+
+	derivedKey := ed25519.NewKeyFromSeed(derivedSeed)
+
+	jwkKey, err := jwk.FromRaw(derivedKey)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrJWKCreation, "failed to create JWK from derived Ed25519 private key")
+	}
+
+	return applyKeyOptions(jwkKey, opts)
+}
@@ -0,0 +1,289 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/MyNextID/cvc-go/pkg"
+)
+
+func TestDeriveSecretKeyWithAlgorithm(t *testing.T) {
+	context := []byte("test-context-for-key-derivation")
+	dst := []byte("CVC-TEST-DST-v1.0")
+
+	t.Run("P256", func(t *testing.T) {
+		masterKey, err := GenerateSecretKeyForCurve(internal.CurveP256)
+		if err != nil {
+			t.Fatalf("Failed to generate master key: %v", err)
+		}
+
+		derivedKey, err := DeriveSecretKeyWithAlgorithm(masterKey, context, dst, AlgorithmP256)
+		if err != nil {
+			t.Fatalf("DeriveSecretKeyWithAlgorithm failed: %v", err)
+		}
+
+		var privateKey ecdsa.PrivateKey
+		if err := derivedKey.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract derived private key: %v", err)
+		}
+		if err := pkg.ValidatePublicKey(privateKey.Curve, privateKey.X, privateKey.Y); err != nil {
+			t.Errorf("Derived public key point is not on the curve: %v", err)
+		}
+
+		shimKey, err := DeriveSecretKey(masterKey, context, dst)
+		if err != nil {
+			t.Fatalf("DeriveSecretKey failed: %v", err)
+		}
+		var shimPrivateKey ecdsa.PrivateKey
+		if err := shimKey.Raw(&shimPrivateKey); err != nil {
+			t.Fatalf("Failed to extract shim private key: %v", err)
+		}
+		if privateKey.D.Cmp(shimPrivateKey.D) != 0 {
+			t.Errorf("DeriveSecretKeyWithAlgorithm(AlgorithmP256) does not match DeriveSecretKey byte-for-byte")
+		}
+	})
+
+	t.Run("P384", func(t *testing.T) {
+		masterKey, err := GenerateSecretKeyForCurve(internal.CurveP384)
+		if err != nil {
+			t.Fatalf("Failed to generate master key: %v", err)
+		}
+
+		derivedKey, err := DeriveSecretKeyWithAlgorithm(masterKey, context, dst, AlgorithmP384)
+		if err != nil {
+			t.Fatalf("DeriveSecretKeyWithAlgorithm failed: %v", err)
+		}
+
+		var privateKey ecdsa.PrivateKey
+		if err := derivedKey.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract derived private key: %v", err)
+		}
+		if err := pkg.ValidatePublicKey(privateKey.Curve, privateKey.X, privateKey.Y); err != nil {
+			t.Errorf("Derived public key point is not on the curve: %v", err)
+		}
+	})
+
+	t.Run("Secp256k1", func(t *testing.T) {
+		masterKey, err := GenerateSecretKeyForCurve(internal.CurveSecp256k1)
+		if err != nil {
+			t.Fatalf("Failed to generate master key: %v", err)
+		}
+
+		derivedKey, err := DeriveSecretKeyWithAlgorithm(masterKey, context, dst, AlgorithmSecp256k1)
+		if err != nil {
+			t.Fatalf("DeriveSecretKeyWithAlgorithm failed: %v", err)
+		}
+
+		var privateKey ecdsa.PrivateKey
+		if err := derivedKey.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract derived private key: %v", err)
+		}
+		if !privateKey.Curve.IsOnCurve(privateKey.X, privateKey.Y) {
+			t.Errorf("Derived public key point is not on the curve")
+		}
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		masterKey, err := GenerateEd25519Key()
+		if err != nil {
+			t.Fatalf("Failed to generate master key: %v", err)
+		}
+
+		derivedKey, err := DeriveSecretKeyWithAlgorithm(masterKey, context, dst, AlgorithmEd25519)
+		if err != nil {
+			t.Fatalf("DeriveSecretKeyWithAlgorithm failed: %v", err)
+		}
+
+		var privateKey ed25519.PrivateKey
+		if err := derivedKey.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract derived private key: %v", err)
+		}
+		if err := pkg.ValidateEd25519PublicKey(privateKey.Public().(ed25519.PublicKey)); err != nil {
+			t.Errorf("Derived public key is invalid: %v", err)
+		}
+
+		// Deterministic: the same master, context and dst always derive
+		// the same child key.
+		again, err := DeriveSecretKeyWithAlgorithm(masterKey, context, dst, AlgorithmEd25519)
+		if err != nil {
+			t.Fatalf("Second DeriveSecretKeyWithAlgorithm failed: %v", err)
+		}
+		var privateKey2 ed25519.PrivateKey
+		if err := again.Raw(&privateKey2); err != nil {
+			t.Fatalf("Failed to extract second derived private key: %v", err)
+		}
+		if !privateKey.Equal(privateKey2) {
+			t.Errorf("Ed25519 derivation is not deterministic")
+		}
+
+		// Different contexts must derive different keys.
+		other, err := DeriveSecretKeyWithAlgorithm(masterKey, []byte("a different context"), dst, AlgorithmEd25519)
+		if err != nil {
+			t.Fatalf("Third DeriveSecretKeyWithAlgorithm failed: %v", err)
+		}
+		var privateKey3 ed25519.PrivateKey
+		if err := other.Raw(&privateKey3); err != nil {
+			t.Fatalf("Failed to extract third derived private key: %v", err)
+		}
+		if privateKey.Equal(privateKey3) {
+			t.Errorf("Derived Ed25519 keys should differ for different contexts")
+		}
+	})
+
+	t.Run("MismatchedAlgorithmIsRejected", func(t *testing.T) {
+		masterKey, err := GenerateSecretKeyForCurve(internal.CurveP256)
+		if err != nil {
+			t.Fatalf("Failed to generate master key: %v", err)
+		}
+
+		if _, err := DeriveSecretKeyWithAlgorithm(masterKey, context, dst, AlgorithmP384); err == nil {
+			t.Errorf("expected an error deriving a P-384 child from a P-256 master, got none")
+		}
+	})
+
+	t.Run("NilMasterKey", func(t *testing.T) {
+		if _, err := DeriveSecretKeyWithAlgorithm(nil, context, dst, AlgorithmP256); err == nil {
+			t.Errorf("expected an error for a nil master key, got none")
+		}
+	})
+}
+
+func TestGenerateSecretKeyWithAlgorithm(t *testing.T) {
+	t.Run("P384", func(t *testing.T) {
+		key, err := GenerateSecretKeyWithAlgorithm(AlgorithmP384)
+		if err != nil {
+			t.Fatalf("GenerateSecretKeyWithAlgorithm failed: %v", err)
+		}
+		var privateKey ecdsa.PrivateKey
+		if err := key.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract private key: %v", err)
+		}
+		if privateKey.Curve.Params().Name != "P-384" {
+			t.Errorf("expected a P-384 key, got %s", privateKey.Curve.Params().Name)
+		}
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		key, err := GenerateSecretKeyWithAlgorithm(AlgorithmEd25519)
+		if err != nil {
+			t.Fatalf("GenerateSecretKeyWithAlgorithm failed: %v", err)
+		}
+		var privateKey ed25519.PrivateKey
+		if err := key.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract derived Ed25519 private key: %v", err)
+		}
+	})
+
+	// Secp256k1 pins AlgorithmSecp256k1 to real curve arithmetic: it once
+	// reached a CurveParams registration whose a=-3 math is wrong for
+	// secp256k1's a=0 curve, so ScalarBaseMult panicked on every call
+	// instead of just returning an off-curve point.
+	t.Run("Secp256k1", func(t *testing.T) {
+		key, err := GenerateSecretKeyWithAlgorithm(AlgorithmSecp256k1)
+		if err != nil {
+			t.Fatalf("GenerateSecretKeyWithAlgorithm failed: %v", err)
+		}
+		var privateKey ecdsa.PrivateKey
+		if err := key.Raw(&privateKey); err != nil {
+			t.Fatalf("Failed to extract private key: %v", err)
+		}
+		if privateKey.Curve.Params().Name != "secp256k1" {
+			t.Errorf("expected a secp256k1 key, got %s", privateKey.Curve.Params().Name)
+		}
+		if !privateKey.Curve.IsOnCurve(privateKey.X, privateKey.Y) {
+			t.Errorf("expected the derived public key point to be on secp256k1")
+		}
+	})
+}
+
+// TestAddPublicKeysEd25519 proves AddPublicKeys' Ed25519 branch performs
+// genuine Edwards group addition rather than, say, silently rejecting OKP
+// keys: adding a key to itself must double it, and adding two different
+// keys must be commutative and distinct from either input.
+func TestAddPublicKeysEd25519(t *testing.T) {
+	key1, err := GenerateSecretKeyWithAlgorithm(AlgorithmEd25519)
+	if err != nil {
+		t.Fatalf("failed to generate first Ed25519 key: %v", err)
+	}
+	key2, err := GenerateSecretKeyWithAlgorithm(AlgorithmEd25519)
+	if err != nil {
+		t.Fatalf("failed to generate second Ed25519 key: %v", err)
+	}
+
+	pub1, err := key1.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive first public key: %v", err)
+	}
+	pub2, err := key2.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive second public key: %v", err)
+	}
+
+	sum, err := AddPublicKeys(pub1, pub2)
+	if err != nil {
+		t.Fatalf("AddPublicKeys failed: %v", err)
+	}
+	reversedSum, err := AddPublicKeys(pub2, pub1)
+	if err != nil {
+		t.Fatalf("AddPublicKeys failed (reversed operands): %v", err)
+	}
+
+	var sumRaw, reversedSumRaw, pub1Raw ed25519.PublicKey
+	if err := sum.Raw(&sumRaw); err != nil {
+		t.Fatalf("failed to extract combined key: %v", err)
+	}
+	if err := reversedSum.Raw(&reversedSumRaw); err != nil {
+		t.Fatalf("failed to extract reversed combined key: %v", err)
+	}
+	if err := pub1.Raw(&pub1Raw); err != nil {
+		t.Fatalf("failed to extract first public key: %v", err)
+	}
+
+	if !sumRaw.Equal(reversedSumRaw) {
+		t.Errorf("AddPublicKeys(key1, key2) != AddPublicKeys(key2, key1)")
+	}
+	if sumRaw.Equal(pub1Raw) {
+		t.Errorf("combined key must not equal either input key")
+	}
+
+	doubled, err := AddPublicKeys(pub1, pub1)
+	if err != nil {
+		t.Fatalf("AddPublicKeys failed for the doubling case: %v", err)
+	}
+	var doubledRaw ed25519.PublicKey
+	if err := doubled.Raw(&doubledRaw); err != nil {
+		t.Fatalf("failed to extract doubled key: %v", err)
+	}
+	if doubledRaw.Equal(pub1Raw) {
+		t.Errorf("doubling a key must not return the same key")
+	}
+}
+
+// TestAddPublicKeysRejectsMixedCurveTypes proves AddPublicKeys refuses to
+// combine an Ed25519 (OKP) key with a Weierstrass (EC) key instead of
+// silently misinterpreting one as the other.
+func TestAddPublicKeysRejectsMixedCurveTypes(t *testing.T) {
+	ecKey, err := GenerateSecretKeyForCurve(internal.CurveP256)
+	if err != nil {
+		t.Fatalf("failed to generate P-256 key: %v", err)
+	}
+	ecPub, err := ecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive P-256 public key: %v", err)
+	}
+
+	edKey, err := GenerateSecretKeyWithAlgorithm(AlgorithmEd25519)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+	edPub, err := edKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive Ed25519 public key: %v", err)
+	}
+
+	if _, err := AddPublicKeys(ecPub, edPub); err == nil {
+		t.Errorf("expected an error combining a P-256 key with an Ed25519 key, got none")
+	}
+}
@@ -329,8 +329,8 @@ func TestPresentation_Create(t *testing.T) {
 	})
 
 	t.Run("ErrorCases", func(t *testing.T) {
-		// Test invalid language
-		invalidLang := Language{Code: "invalid"}
+		// Test invalid language (zero value resolves to the undefined tag)
+		invalidLang := Language{}
 		presentation := &Presentation{
 			Languages: []Language{invalidLang},
 			Groups:    []Group{},
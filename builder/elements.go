@@ -5,7 +5,7 @@ import "fmt"
 type Element struct {
 	// Titles is a required field.
 	// It is required to have the same length of titles as there are languages in the presentation.
-	Titles map[Language]string
+	Titles map[Language]string `validate:"omitempty,dive,keys,bcp47,endkeys,required"`
 
 	// Optional defines if the Element is an optional field - which means it could have empty values.
 	Optional bool
@@ -28,7 +28,7 @@ type Element struct {
 	// It requires a pointer to a field in payload.
 	//
 	// Example value for en would be "/issuer/legalName/en"
-	Values map[Language]string
+	Values map[Language]string `validate:"omitempty,dive,keys,bcp47,endkeys,required"`
 }
 
 func (e *Element) NewElement(titles map[Language]string) (*Element, error) {
@@ -42,22 +42,18 @@ func (e *Element) NewElement(titles map[Language]string) (*Element, error) {
 	}, nil
 }
 
+// ValidateElement runs element through the same validator.Validate used by
+// Presentation.validate, wrapping it in a throwaway Presentation that shares
+// p's Languages so the registered struct-level validation has the context
+// it needs to check title/value language completeness.
 func (p *Presentation) ValidateElement(element *Element) error {
-	titleOK := element.TitleContainsAllLanguages(p.Languages)
-	if !titleOK {
-		return fmt.Errorf("title languages on element do not match languages on the presentation")
+	synthetic := &Presentation{
+		Languages: p.Languages,
+		Groups:    []Group{{Elements: []Element{*element}}},
 	}
 
-	if element.Multilanguage {
-		valuesOK := element.VerifyMultiLangValues(p.Languages)
-		if !valuesOK {
-			return fmt.Errorf("element is multilang and the element values are either empty or do not match languages on the presentation")
-		}
-	} else {
-		// Check if element.Value is set
-		if element.Value == "" {
-			return fmt.Errorf("element is not multilang and the element value is an empty string - not allowed")
-		}
+	if err := validate.Struct(synthetic); err != nil {
+		return formatValidationError(err)
 	}
 
 	return nil
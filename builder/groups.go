@@ -4,8 +4,8 @@ import "fmt"
 
 type Group struct {
 	ID       uint
-	Titles   map[Language]string
-	Elements []Element
+	Titles   map[Language]string `validate:"omitempty,dive,keys,bcp47,endkeys,required"`
+	Elements []Element           `validate:"required,dive"`
 }
 
 // NewGroup creates a new Group with validation
@@ -28,9 +28,13 @@ func NewGroup(elements []Element, id uint, titles map[Language]string) (*Group,
 	}, nil
 }
 
+// Validate checks g in isolation: that it has at least one element, and
+// that every element's Titles/Values keys are well-formed BCP 47 codes.
+// Title/value completeness against a Presentation's declared languages is
+// checked separately by Presentation.validate, which has that context.
 func (g *Group) Validate() error {
-	if len(g.Elements) == 0 {
-		return fmt.Errorf("requires atleast one element in a group with id: %v", g.ID)
+	if err := validate.Struct(g); err != nil {
+		return formatValidationError(err)
 	}
 
 	return nil
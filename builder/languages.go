@@ -3,33 +3,58 @@ package builder
 import (
 	"fmt"
 
-	"github.com/emvi/iso-639-1"
+	"golang.org/x/text/language"
 )
 
+// Language wraps a canonical BCP 47 language tag. Representing codes as a
+// parsed language.Tag (rather than an opaque string) lets this package
+// accept region/script/variant subtags such as "en-US", "sl-Latn-SI", or
+// "zh-Hant", and guarantees that differently-cased or differently-ordered
+// spellings of the same tag canonicalize to the same Language, so they
+// collapse consistently as map keys in Element.Titles/Values.
 type Language struct {
-	Code string
+	tag language.Tag
 }
 
-// NewLanguage creates a new Language with validation
+// NewLanguage parses code as a BCP 47 language tag and returns a Language
+// holding its canonical form. Malformed input is rejected through
+// language.Parse's error; the undefined tag ("und") is well-formed and
+// parses without error, so that case is rejected explicitly here.
 func NewLanguage(code string) (*Language, error) {
-	if !iso6391.ValidCode(code) {
-		return nil, fmt.Errorf("invalid ISO 639-1 language Code: %s", code)
+	tag, err := language.Parse(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid BCP 47 language Code: %s: %w", code, err)
 	}
-	return &Language{Code: code}, nil
+	if tag == language.Und {
+		return nil, fmt.Errorf("invalid BCP 47 language Code: %s resolves to the undefined language", code)
+	}
+
+	return &Language{tag: tag}, nil
 }
 
-func (l Language) IsValid() bool {
-	return iso6391.ValidCode(l.Code)
+// Tag returns the underlying canonical BCP 47 tag.
+func (l Language) Tag() language.Tag {
+	return l.tag
+}
+
+// String returns the canonical string form of the tag, e.g. "en-US".
+func (l Language) String() string {
+	return l.tag.String()
+}
+
+// Equal reports whether l and other are the same canonical tag.
+func (l Language) Equal(other Language) bool {
+	return l.tag == other.tag
 }
 
-func (l Language) GetName() string {
-	return iso6391.Name(l.Code)
+func (l Language) IsValid() bool {
+	return l.tag != language.Und
 }
 
 // Validate validates the language Code and returns an error if invalid
 func (l Language) Validate() error {
 	if !l.IsValid() {
-		return fmt.Errorf("invalid ISO 639-1 language Code: %s", l.Code)
+		return fmt.Errorf("invalid BCP 47 language Code: %s", l.String())
 	}
 	return nil
 }
@@ -0,0 +1,75 @@
+package builder
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestNewLanguageCanonicalizesCase(t *testing.T) {
+	upper, err := NewLanguage("EN")
+	if err != nil {
+		t.Fatalf("NewLanguage(\"EN\") returned an error: %v", err)
+	}
+	lower, err := NewLanguage("en")
+	if err != nil {
+		t.Fatalf("NewLanguage(\"en\") returned an error: %v", err)
+	}
+
+	if !upper.Equal(*lower) {
+		t.Fatalf("expected %q and %q to canonicalize to the same Language", upper, lower)
+	}
+	if upper.String() != "en" {
+		t.Fatalf("String() = %q, want %q", upper.String(), "en")
+	}
+}
+
+func TestNewLanguageSupportsRegionAndScriptSubtags(t *testing.T) {
+	for _, code := range []string{"en-US", "sl-Latn-SI", "zh-Hant", "de-CH"} {
+		lang, err := NewLanguage(code)
+		if err != nil {
+			t.Fatalf("NewLanguage(%q) returned an error: %v", code, err)
+		}
+		if lang.String() != code {
+			t.Errorf("NewLanguage(%q).String() = %q, want %q", code, lang.String(), code)
+		}
+	}
+}
+
+func TestNewLanguageRejectsUndefinedTag(t *testing.T) {
+	if _, err := NewLanguage("und"); err == nil {
+		t.Fatalf("expected NewLanguage(\"und\") to be rejected")
+	}
+}
+
+func TestLanguageAsMapKeyCollapsesByCanonicalForm(t *testing.T) {
+	enUpper, _ := NewLanguage("EN")
+	enLower, _ := NewLanguage("en")
+
+	titles := map[Language]string{*enLower: "English"}
+	if _, ok := titles[*enUpper]; !ok {
+		t.Fatalf("expected canonicalized EN to resolve the same map entry as en")
+	}
+}
+
+func TestPresentationMatchPicksBestFit(t *testing.T) {
+	en, _ := NewLanguage("en")
+	sl, _ := NewLanguage("sl")
+
+	presentation := &Presentation{Languages: []Language{*en, *sl}}
+
+	best := presentation.Match([]language.Tag{language.MustParse("sl-SI")})
+	if !best.Equal(*sl) {
+		t.Fatalf("Match returned %q, want %q", best, sl)
+	}
+}
+
+func TestPresentationMatchFallsBackToFirstLanguage(t *testing.T) {
+	en, _ := NewLanguage("en")
+	presentation := &Presentation{Languages: []Language{*en}}
+
+	best := presentation.Match([]language.Tag{language.MustParse("fr")})
+	if !best.Equal(*en) {
+		t.Fatalf("Match returned %q, want fallback %q", best, en)
+	}
+}
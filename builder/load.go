@@ -0,0 +1,151 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+)
+
+// elementDocument, groupDocument and presentationDocument mirror the shape
+// Presentation.Create emits (see buildOutput's OutputElement/OutputGroup/
+// OutputPresentation), but as input: struct tags here are the single
+// source of truth LoadPresentationJSON and LoadPresentationYAML both parse
+// against, so the two formats can never drift apart.
+type elementDocument struct {
+	Title         map[string]string `json:"title"`
+	Multilanguage bool              `json:"multilanguage,omitempty"`
+	Optional      bool              `json:"optional,omitempty"`
+	Format        FormatType        `json:"format,omitempty"`
+	Value         string            `json:"value,omitempty"`
+	Values        map[string]string `json:"values,omitempty"`
+}
+
+type groupDocument struct {
+	ID       uint              `json:"id"`
+	Title    map[string]string `json:"title,omitempty"`
+	Elements []elementDocument `json:"elements"`
+}
+
+type presentationDocument struct {
+	Languages []string        `json:"languages"`
+	Groups    []groupDocument `json:"groups"`
+}
+
+// LoadPresentationJSON parses a declarative presentation document into a
+// Presentation, running it through the same validation Create uses before
+// returning it.
+func LoadPresentationJSON(data []byte) (*Presentation, error) {
+	var doc presentationDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal presentation document: %w", err)
+	}
+
+	return presentationFromDocument(doc)
+}
+
+// LoadPresentationYAML is LoadPresentationJSON for YAML input. Following
+// the github.com/ghodss/yaml pattern, it converts the YAML to JSON first
+// and reuses the JSON path rather than unmarshalling YAML directly, so
+// there is no second set of parsing rules to keep in sync.
+func LoadPresentationYAML(data []byte) (*Presentation, error) {
+	jsonBytes, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert presentation YAML to JSON: %w", err)
+	}
+
+	return LoadPresentationJSON(jsonBytes)
+}
+
+func presentationFromDocument(doc presentationDocument) (*Presentation, error) {
+	languages := make([]Language, len(doc.Languages))
+	for i, code := range doc.Languages {
+		lang, err := NewLanguage(code)
+		if err != nil {
+			return nil, fmt.Errorf("languages[%d]: %w", i, err)
+		}
+		languages[i] = *lang
+	}
+
+	groups := make([]Group, len(doc.Groups))
+	for i, groupDoc := range doc.Groups {
+		group, err := groupFromDocument(groupDoc)
+		if err != nil {
+			return nil, fmt.Errorf("groups[%d]: %w", i, err)
+		}
+		groups[i] = *group
+	}
+
+	presentation := &Presentation{Languages: languages, Groups: groups}
+	if err := presentation.validate(); err != nil {
+		return nil, err
+	}
+
+	return presentation, nil
+}
+
+func groupFromDocument(doc groupDocument) (*Group, error) {
+	titles, err := titleMapFromDocument(doc.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := make([]Element, len(doc.Elements))
+	for i, elementDoc := range doc.Elements {
+		element, err := elementFromDocument(elementDoc)
+		if err != nil {
+			return nil, fmt.Errorf("elements[%d]: %w", i, err)
+		}
+		elements[i] = *element
+	}
+
+	return NewGroup(elements, doc.ID, titles)
+}
+
+func elementFromDocument(doc elementDocument) (*Element, error) {
+	titles, err := titleMapFromDocument(doc.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	element := Element{
+		Titles:        titles,
+		Optional:      doc.Optional,
+		Multilanguage: doc.Multilanguage,
+		Value:         doc.Value,
+	}
+
+	if doc.Format != "" {
+		element.Format = ElementFormat{Type: doc.Format}
+	}
+
+	if doc.Multilanguage {
+		values, err := titleMapFromDocument(doc.Values)
+		if err != nil {
+			return nil, err
+		}
+		element.Values = values
+	}
+
+	return &element, nil
+}
+
+// titleMapFromDocument converts a language-code-keyed string map from a
+// document into one keyed by the parsed Language values the rest of the
+// package uses.
+func titleMapFromDocument(raw map[string]string) (map[Language]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[Language]string, len(raw))
+	for code, value := range raw {
+		lang, err := NewLanguage(code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid language code %q: %w", code, err)
+		}
+		result[*lang] = value
+	}
+
+	return result, nil
+}
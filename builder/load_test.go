@@ -0,0 +1,113 @@
+package builder
+
+import "testing"
+
+func TestLoadPresentationJSONMatchesYAML(t *testing.T) {
+	jsonDoc := []byte(`{
+		"languages": ["en", "sl"],
+		"groups": [
+			{
+				"id": 1,
+				"title": {"en": "Basics", "sl": "Osnove"},
+				"elements": [
+					{
+						"title": {"en": "Full name", "sl": "Polno ime"},
+						"value": "/credentialSubject/fullName"
+					},
+					{
+						"title": {"en": "Issued on", "sl": "Datum izdaje"},
+						"format": "date-time",
+						"value": "/issuanceDate"
+					}
+				]
+			}
+		]
+	}`)
+
+	yamlDoc := []byte(`
+languages:
+  - en
+  - sl
+groups:
+  - id: 1
+    title:
+      en: Basics
+      sl: Osnove
+    elements:
+      - title:
+          en: Full name
+          sl: Polno ime
+        value: /credentialSubject/fullName
+      - title:
+          en: Issued on
+          sl: Datum izdaje
+        format: date-time
+        value: /issuanceDate
+`)
+
+	fromJSON, err := LoadPresentationJSON(jsonDoc)
+	if err != nil {
+		t.Fatalf("LoadPresentationJSON returned an error: %v", err)
+	}
+
+	fromYAML, err := LoadPresentationYAML(yamlDoc)
+	if err != nil {
+		t.Fatalf("LoadPresentationYAML returned an error: %v", err)
+	}
+
+	for _, presentation := range []*Presentation{fromJSON, fromYAML} {
+		if len(presentation.Languages) != 2 {
+			t.Fatalf("expected 2 languages, got %d", len(presentation.Languages))
+		}
+		if len(presentation.Groups) != 1 {
+			t.Fatalf("expected 1 group, got %d", len(presentation.Groups))
+		}
+
+		group := presentation.Groups[0]
+		if len(group.Elements) != 2 {
+			t.Fatalf("expected 2 elements, got %d", len(group.Elements))
+		}
+		if group.Elements[0].Value != "/credentialSubject/fullName" {
+			t.Errorf("name value = %q, want %q", group.Elements[0].Value, "/credentialSubject/fullName")
+		}
+		if group.Elements[1].Format.String() != "date-time" {
+			t.Errorf("issued on format = %q, want %q", group.Elements[1].Format.String(), "date-time")
+		}
+	}
+}
+
+func TestLoadPresentationJSONRejectsInvalidLanguage(t *testing.T) {
+	doc := []byte(`{"languages": ["not-a-real-bcp47-tag-???"], "groups": []}`)
+
+	if _, err := LoadPresentationJSON(doc); err == nil {
+		t.Fatalf("expected an error for an invalid language code")
+	}
+}
+
+func TestLoadPresentationJSONRejectsMissingTitleLanguage(t *testing.T) {
+	doc := []byte(`{
+		"languages": ["en", "sl"],
+		"groups": [
+			{
+				"id": 1,
+				"elements": [
+					{
+						"title": {"en": "Full name"},
+						"value": "/credentialSubject/fullName"
+					}
+				]
+			}
+		]
+	}`)
+
+	_, err := LoadPresentationJSON(doc)
+	if err == nil {
+		t.Fatalf("expected an error for an element missing a title in a declared language")
+	}
+}
+
+func TestLoadPresentationYAMLRejectsMalformedYAML(t *testing.T) {
+	if _, err := LoadPresentationYAML([]byte("languages: [en\n")); err == nil {
+		t.Fatalf("expected an error for malformed YAML")
+	}
+}
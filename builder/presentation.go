@@ -3,11 +3,32 @@ package builder
 import (
 	"encoding/json"
 	"fmt"
+
+	"golang.org/x/text/language"
 )
 
 type Presentation struct {
-	Languages []Language
-	Groups    []Group
+	Languages []Language `validate:"dive,bcp47"`
+	Groups    []Group    `validate:"dive"`
+}
+
+// Match returns the presentation language that best fits requested (e.g. a
+// viewer's parsed Accept-Language header), using language.NewMatcher's
+// standard BCP 47 matching algorithm over p.Languages.
+func (p *Presentation) Match(requested []language.Tag) Language {
+	if len(p.Languages) == 0 {
+		return Language{}
+	}
+
+	tags := make([]language.Tag, len(p.Languages))
+	for i, lang := range p.Languages {
+		tags[i] = lang.tag
+	}
+
+	matcher := language.NewMatcher(tags)
+	_, index, _ := matcher.Match(requested...)
+
+	return p.Languages[index]
 }
 
 func (p *Presentation) Create() ([]byte, error) {
@@ -28,33 +49,14 @@ func (p *Presentation) Create() ([]byte, error) {
 	return jsonBytes, nil
 }
 
+// validate runs the whole presentation tree through the package's shared
+// validator.Validate: struct tags on Presentation/Group/Element check
+// language codes and non-empty values, and the registered struct-level
+// validator on Element enforces that Titles (and Values, when Multilanguage)
+// cover every language declared here, skipping elements marked Optional.
 func (p *Presentation) validate() error {
-	// Validate languages
-	for _, lang := range p.Languages {
-		if !lang.IsValid() {
-			return fmt.Errorf("language: %v is not valid", lang)
-		}
-	}
-
-	// Validate groups and elements consistency
-	for _, group := range p.Groups {
-		err := group.Validate()
-		if err != nil {
-			return err
-		}
-
-		// Validate elements multilanguage requirements
-		for _, element := range group.Elements {
-			// Skip validation if optional
-			if element.Optional {
-				continue
-			}
-
-			err = p.ValidateElement(&element)
-			if err != nil {
-				return err
-			}
-		}
+	if err := validate.Struct(p); err != nil {
+		return formatValidationError(err)
 	}
 
 	return nil
@@ -85,7 +87,7 @@ func (p *Presentation) buildOutput() map[string]interface{} {
 	// Transform languages
 	languages := make([]string, len(p.Languages))
 	for i, lang := range p.Languages {
-		languages[i] = lang.Code
+		languages[i] = lang.String()
 	}
 
 	// Transform groups
@@ -104,7 +106,7 @@ func (p *Presentation) buildOutput() map[string]interface{} {
 			// This might need adjustment based on your actual requirements.
 			titleMap := make(map[string]string)
 			for _, lang := range p.Languages {
-				titleMap[lang.Code] = group.Titles[lang]
+				titleMap[lang.String()] = group.Titles[lang]
 			}
 			outputGroup.Title = titleMap
 		}
@@ -119,7 +121,7 @@ func (p *Presentation) buildOutput() map[string]interface{} {
 
 			// Transform titles
 			for lang, title := range element.Titles {
-				outputElement.Title[lang.Code] = title
+				outputElement.Title[lang.String()] = title
 			}
 
 			// Set format if not empty
@@ -131,7 +133,7 @@ func (p *Presentation) buildOutput() map[string]interface{} {
 			if element.Multilanguage {
 				outputElement.Values = make(map[string]string)
 				for lang, value := range element.Values {
-					outputElement.Values[lang.Code] = value
+					outputElement.Values[lang.String()] = value
 				}
 			} else {
 				outputElement.Value = element.Value
@@ -0,0 +1,272 @@
+package builder
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RenderedElement is one Element resolved against a verifiable credential
+// document and ready for direct display.
+type RenderedElement struct {
+	Label    string
+	Value    string
+	Optional bool
+	Missing  bool
+}
+
+// RenderedGroup is one Group resolved against a verifiable credential
+// document.
+type RenderedGroup struct {
+	GroupTitle string
+	Elements   []RenderedElement
+}
+
+// RenderedPresentation is a Presentation fully resolved against a
+// verifiable credential document for a single display language.
+type RenderedPresentation []RenderedGroup
+
+// MissingValueError identifies a required (non-optional) element whose
+// JSON Pointer did not resolve against the rendered credential.
+type MissingValueError struct {
+	Pointer string
+}
+
+func (e *MissingValueError) Error() string {
+	return fmt.Sprintf("required element pointer %q did not resolve against the credential", e.Pointer)
+}
+
+// FormatRenderer renders a resolved JSON value for display in lang, e.g.
+// reformatting a date-time or duration per locale. Register additional
+// formats (currency, mrz, ...) with RegisterFormatRenderer without
+// modifying this package, mirroring how NewDateTimeFormat/NewDurationFormat
+// are registered as ElementFormat constructors today.
+type FormatRenderer interface {
+	Render(value interface{}, lang Language) (string, error)
+}
+
+var formatRenderers = map[FormatType]FormatRenderer{
+	FormatDateTime: dateTimeFormatRenderer{},
+	FormatDuration: durationFormatRenderer{},
+}
+
+// RegisterFormatRenderer registers (or replaces) the FormatRenderer used to
+// render elements whose Format.Type is formatType.
+func RegisterFormatRenderer(formatType FormatType, renderer FormatRenderer) {
+	formatRenderers[formatType] = renderer
+}
+
+// Render resolves every Group/Element in p against vc, a verifiable
+// credential document, for lang, applying each Element's declared Format.
+// Optional elements whose pointer resolves to null/absent are reported as
+// Missing rather than erroring; a required element that fails to resolve
+// aborts with a *MissingValueError identifying the offending pointer.
+func (p *Presentation) Render(vc []byte, lang Language) (RenderedPresentation, error) {
+	var doc interface{}
+	if err := json.Unmarshal(vc, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal verifiable credential: %w", err)
+	}
+
+	rendered := make(RenderedPresentation, 0, len(p.Groups))
+	for _, group := range p.Groups {
+		renderedGroup := RenderedGroup{
+			GroupTitle: group.Titles[lang],
+			Elements:   make([]RenderedElement, 0, len(group.Elements)),
+		}
+
+		for _, element := range group.Elements {
+			renderedElement, err := renderElement(doc, element, lang)
+			if err != nil {
+				return nil, err
+			}
+			renderedGroup.Elements = append(renderedGroup.Elements, renderedElement)
+		}
+
+		rendered = append(rendered, renderedGroup)
+	}
+
+	return rendered, nil
+}
+
+func renderElement(doc interface{}, element Element, lang Language) (RenderedElement, error) {
+	label := element.Titles[lang]
+
+	pointer := element.Value
+	if element.Multilanguage {
+		pointer = element.Values[lang]
+	}
+
+	value, found := resolveJSONPointer(doc, pointer)
+	if !found || value == nil {
+		if element.Optional {
+			return RenderedElement{Label: label, Optional: true, Missing: true}, nil
+		}
+		return RenderedElement{}, &MissingValueError{Pointer: pointer}
+	}
+
+	rendered, err := renderValue(element.Format, value, lang)
+	if err != nil {
+		return RenderedElement{}, fmt.Errorf("pointer %q: %w", pointer, err)
+	}
+
+	return RenderedElement{Label: label, Value: rendered, Optional: element.Optional}, nil
+}
+
+func renderValue(format ElementFormat, value interface{}, lang Language) (string, error) {
+	if format.IsEmpty() {
+		return fmt.Sprintf("%v", value), nil
+	}
+
+	renderer, ok := formatRenderers[format.Type]
+	if !ok {
+		return "", fmt.Errorf("no FormatRenderer registered for format %q", format.Type)
+	}
+
+	return renderer.Render(value, lang)
+}
+
+// resolveJSONPointer resolves an RFC 6901 JSON Pointer (e.g.
+// "/issuer/legalName/en") against doc, the result of unmarshaling a JSON
+// document into interface{}. found is false when any segment of the
+// pointer does not exist in doc.
+func resolveJSONPointer(doc interface{}, pointer string) (value interface{}, found bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if pointer[0] != '/' {
+		return nil, false
+	}
+
+	current := doc
+	for _, segment := range strings.Split(pointer[1:], "/") {
+		segment = strings.ReplaceAll(segment, "~1", "/")
+		segment = strings.ReplaceAll(segment, "~0", "~")
+
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[segment]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			current = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// dateTimeFormatRenderer renders FormatDateTime elements by parsing the
+// resolved value as time.RFC3339 and re-rendering it in a locale-specific
+// layout.
+type dateTimeFormatRenderer struct{}
+
+func (dateTimeFormatRenderer) Render(value interface{}, lang Language) (string, error) {
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("date-time format requires a string value, got %T", value)
+	}
+
+	parsed, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %q as RFC 3339 date-time: %w", str, err)
+	}
+
+	return parsed.Format(dateTimeLayoutForLanguage(lang)), nil
+}
+
+func dateTimeLayoutForLanguage(lang Language) string {
+	base, _ := lang.Tag().Base()
+	switch base.String() {
+	case "de":
+		return "02.01.2006 15:04"
+	case "sl":
+		return "2.1.2006 15:04"
+	default:
+		return "Jan 2, 2006 3:04 PM"
+	}
+}
+
+// durationFormatRenderer renders FormatDuration elements by parsing the
+// resolved value as an ISO 8601 duration into a time.Duration and
+// formatting it as "N h M min" using locale-specific unit labels.
+type durationFormatRenderer struct{}
+
+// iso8601DurationPattern matches the week/day/hour/minute/second subset of
+// ISO 8601 durations; calendar components (years, months) are rejected
+// since they aren't a fixed time.Duration.
+var iso8601DurationPattern = regexp.MustCompile(`^P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+func (durationFormatRenderer) Render(value interface{}, lang Language) (string, error) {
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("duration format requires a string value, got %T", value)
+	}
+
+	duration, err := parseISO8601Duration(str)
+	if err != nil {
+		return "", err
+	}
+
+	return formatDuration(duration, lang), nil
+}
+
+func parseISO8601Duration(value string) (time.Duration, error) {
+	match := iso8601DurationPattern.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("unsupported ISO 8601 duration: %q", value)
+	}
+
+	weeks, _ := strconv.Atoi(match[1])
+	days, _ := strconv.Atoi(match[2])
+	hours, _ := strconv.Atoi(match[3])
+	minutes, _ := strconv.Atoi(match[4])
+	seconds, _ := strconv.ParseFloat(match[5], 64)
+
+	total := time.Duration(weeks)*7*24*time.Hour +
+		time.Duration(days)*24*time.Hour +
+		time.Duration(hours)*time.Hour +
+		time.Duration(minutes)*time.Minute +
+		time.Duration(seconds*float64(time.Second))
+
+	if total == 0 && value != "PT0S" {
+		return 0, fmt.Errorf("duration %q did not contain any recognized component", value)
+	}
+
+	return total, nil
+}
+
+func formatDuration(d time.Duration, lang Language) string {
+	hours := int(d / time.Hour)
+	minutes := int((d % time.Hour) / time.Minute)
+	hourUnit, minuteUnit := durationUnitsForLanguage(lang)
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%d %s %d %s", hours, hourUnit, minutes, minuteUnit)
+	case hours > 0:
+		return fmt.Sprintf("%d %s", hours, hourUnit)
+	default:
+		return fmt.Sprintf("%d %s", minutes, minuteUnit)
+	}
+}
+
+func durationUnitsForLanguage(lang Language) (hourUnit, minuteUnit string) {
+	base, _ := lang.Tag().Base()
+	switch base.String() {
+	case "de":
+		return "Std", "Min"
+	default:
+		return "h", "min"
+	}
+}
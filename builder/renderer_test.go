@@ -0,0 +1,136 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPresentationRenderResolvesValues(t *testing.T) {
+	en, _ := NewLanguage("en")
+
+	nameElement := Element{
+		Titles: map[Language]string{*en: "Full name"},
+		Value:  "/credentialSubject/fullName",
+	}
+	issuedOnElement := Element{
+		Titles: map[Language]string{*en: "Issued on"},
+		Format: NewDateTimeFormat(),
+		Value:  "/issuanceDate",
+	}
+	workloadElement := Element{
+		Titles: map[Language]string{*en: "Duration"},
+		Format: NewDurationFormat(),
+		Value:  "/credentialSubject/workload",
+	}
+	missingOptional := Element{
+		Titles:   map[Language]string{*en: "Middle name"},
+		Optional: true,
+		Value:    "/credentialSubject/middleName",
+	}
+
+	group, err := NewGroup(
+		[]Element{nameElement, issuedOnElement, workloadElement, missingOptional},
+		1,
+		map[Language]string{*en: "Basics"},
+	)
+	if err != nil {
+		t.Fatalf("NewGroup returned an error: %v", err)
+	}
+
+	presentation := &Presentation{Languages: []Language{*en}, Groups: []Group{*group}}
+
+	vc := []byte(`{
+		"credentialSubject": {"fullName": "Jane Doe", "workload": "PT8H30M"},
+		"issuanceDate": "2024-01-15T10:00:00Z"
+	}`)
+
+	rendered, err := presentation.Render(vc, *en)
+	if err != nil {
+		t.Fatalf("Render returned an error: %v", err)
+	}
+
+	if len(rendered) != 1 || rendered[0].GroupTitle != "Basics" {
+		t.Fatalf("unexpected rendered groups: %+v", rendered)
+	}
+
+	elements := rendered[0].Elements
+	if elements[0].Value != "Jane Doe" {
+		t.Errorf("name = %q, want %q", elements[0].Value, "Jane Doe")
+	}
+	if elements[1].Value != "Jan 15, 2024 10:00 AM" {
+		t.Errorf("issued on = %q, want %q", elements[1].Value, "Jan 15, 2024 10:00 AM")
+	}
+	if elements[2].Value != "8 h 30 min" {
+		t.Errorf("workload = %q, want %q", elements[2].Value, "8 h 30 min")
+	}
+	if !elements[3].Missing || elements[3].Value != "" {
+		t.Errorf("middle name = %+v, want a missing optional element", elements[3])
+	}
+}
+
+func TestPresentationRenderErrorsOnMissingRequiredPointer(t *testing.T) {
+	en, _ := NewLanguage("en")
+
+	element := Element{
+		Titles: map[Language]string{*en: "Full name"},
+		Value:  "/credentialSubject/fullName",
+	}
+	group, err := NewGroup([]Element{element}, 1, map[Language]string{})
+	if err != nil {
+		t.Fatalf("NewGroup returned an error: %v", err)
+	}
+
+	presentation := &Presentation{Languages: []Language{*en}, Groups: []Group{*group}}
+
+	_, err = presentation.Render([]byte(`{}`), *en)
+	if err == nil {
+		t.Fatalf("expected Render to fail for a missing required pointer")
+	}
+
+	var missingErr *MissingValueError
+	if !errors.As(err, &missingErr) {
+		t.Fatalf("expected a *MissingValueError, got %T: %v", err, err)
+	}
+	if missingErr.Pointer != "/credentialSubject/fullName" {
+		t.Errorf("MissingValueError.Pointer = %q, want %q", missingErr.Pointer, "/credentialSubject/fullName")
+	}
+}
+
+func TestRegisterFormatRendererAddsCustomFormat(t *testing.T) {
+	const formatCurrency FormatType = "currency"
+
+	RegisterFormatRenderer(formatCurrency, currencyFormatRendererForTest{})
+
+	en, _ := NewLanguage("en")
+	element := Element{
+		Titles: map[Language]string{*en: "Fee"},
+		Format: ElementFormat{Type: formatCurrency},
+		Value:  "/credentialSubject/fee",
+	}
+	group, err := NewGroup([]Element{element}, 1, map[Language]string{})
+	if err != nil {
+		t.Fatalf("NewGroup returned an error: %v", err)
+	}
+
+	presentation := &Presentation{Languages: []Language{*en}, Groups: []Group{*group}}
+
+	rendered, err := presentation.Render([]byte(`{"credentialSubject": {"fee": 19.9}}`), *en)
+	if err != nil {
+		t.Fatalf("Render returned an error: %v", err)
+	}
+
+	if got := rendered[0].Elements[0].Value; got != "EUR 19.90" {
+		t.Errorf("fee = %q, want %q", got, "EUR 19.90")
+	}
+}
+
+type currencyFormatRendererForTest struct{}
+
+func (currencyFormatRendererForTest) Render(value interface{}, lang Language) (string, error) {
+	amount, ok := value.(float64)
+	if !ok {
+		return "", errors.New("currency format requires a numeric value")
+	}
+	return fmt.Sprintf("EUR %.2f", amount), nil
+}
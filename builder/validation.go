@@ -0,0 +1,94 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validate is the shared validator.Validate instance for the package. It
+// drives Presentation.validate, Group.Validate and Presentation.ValidateElement,
+// replacing the hand-rolled loops those used to run themselves: struct tags
+// on Element/Group/Presentation declare the shape constraints (non-empty
+// languages, valid BCP 47 codes on every Titles/Values key), and a
+// registered struct-level validator enforces the one invariant a tag can't
+// express on its own - that an Element's Titles (and, if Multilanguage, its
+// Values) cover every language declared on the enclosing Presentation.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+
+	if err := v.RegisterValidation("bcp47", validateBCP47Tag); err != nil {
+		panic(fmt.Sprintf("builder: failed to register bcp47 validator: %v", err))
+	}
+
+	v.RegisterStructValidation(validateElementStructLevel, Element{})
+
+	return v
+}
+
+// validateBCP47Tag backs the "bcp47" tag used on Language fields and on the
+// Language keys of Titles/Values maps.
+func validateBCP47Tag(fl validator.FieldLevel) bool {
+	lang, ok := fl.Field().Interface().(Language)
+	if !ok {
+		return false
+	}
+	return lang.IsValid()
+}
+
+// validateElementStructLevel enforces that, unless the element is Optional,
+// its Titles cover every language declared on the enclosing Presentation,
+// and - when Multilanguage - so do its Values.
+func validateElementStructLevel(sl validator.StructLevel) {
+	element := sl.Current().Interface().(Element)
+	if element.Optional {
+		return
+	}
+
+	// Top is whatever validate.Struct was handed, which is a *Presentation
+	// for Presentation.validate and Presentation.ValidateElement.
+	var presentation Presentation
+	switch top := sl.Top().Interface().(type) {
+	case Presentation:
+		presentation = top
+	case *Presentation:
+		presentation = *top
+	default:
+		return
+	}
+
+	if !element.TitleContainsAllLanguages(presentation.Languages) {
+		sl.ReportError(element.Titles, "Titles", "Titles", "completelanguages", "")
+	}
+
+	if element.Multilanguage {
+		if !element.VerifyMultiLangValues(presentation.Languages) {
+			sl.ReportError(element.Values, "Values", "Values", "completelanguages", "")
+		}
+	} else if element.Value == "" {
+		sl.ReportError(element.Value, "Value", "Value", "required", "")
+	}
+}
+
+// formatValidationError turns the first validator.FieldError in err into a
+// plain error carrying the struct field path (e.g.
+// "Groups[2].Elements[0].Values[fr]"), with the synthetic root struct name
+// validator.Validate prefixes namespaces with stripped off.
+func formatValidationError(err error) error {
+	var fieldErrs validator.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		return err
+	}
+
+	fieldErr := fieldErrs[0]
+	path := fieldErr.Namespace()
+	if idx := strings.Index(path, "."); idx != -1 {
+		path = path[idx+1:]
+	}
+
+	return fmt.Errorf("%s: failed validation on %q", path, fieldErr.Tag())
+}
@@ -0,0 +1,79 @@
+package builder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRejectsUndefinedLanguageKey(t *testing.T) {
+	en, _ := NewLanguage("en")
+
+	element := Element{
+		Titles: map[Language]string{
+			*en:        "Full name",
+			Language{}: "Bad title",
+		},
+		Value: "/credentialSubject/fullName",
+	}
+
+	group, err := NewGroup([]Element{element}, 1, map[Language]string{})
+	if err != nil {
+		t.Fatalf("NewGroup returned an error: %v", err)
+	}
+
+	presentation := &Presentation{Languages: []Language{*en}, Groups: []Group{*group}}
+
+	_, err = presentation.Create()
+	if err == nil {
+		t.Fatalf("expected an error for a Titles key that isn't a valid BCP 47 tag")
+	}
+	if !strings.Contains(err.Error(), "Titles") {
+		t.Errorf("error = %q, want it to mention the Titles field", err.Error())
+	}
+}
+
+func TestValidateReportsFieldPath(t *testing.T) {
+	en, _ := NewLanguage("en")
+	fr, _ := NewLanguage("fr")
+
+	incomplete := Element{
+		Titles: map[Language]string{
+			*en: "Fee",
+			*fr: "Frais",
+		},
+		Multilanguage: true,
+		Values: map[Language]string{
+			*en: "/credentialSubject/fee",
+			// fr missing
+		},
+	}
+
+	group, err := NewGroup([]Element{incomplete}, 1, map[Language]string{})
+	if err != nil {
+		t.Fatalf("NewGroup returned an error: %v", err)
+	}
+
+	presentation := &Presentation{Languages: []Language{*en, *fr}, Groups: []Group{*group}}
+
+	_, err = presentation.Create()
+	if err == nil {
+		t.Fatalf("expected an error for incomplete multilanguage Values")
+	}
+	if !strings.Contains(err.Error(), "Groups[0].Elements[0].Values") {
+		t.Errorf("error = %q, want it to carry the field path", err.Error())
+	}
+}
+
+func TestGroupValidateChecksElementsInIsolation(t *testing.T) {
+	en, _ := NewLanguage("en")
+	group := Group{ID: 1, Elements: []Element{{Titles: map[Language]string{*en: "Name"}, Value: "/name"}}}
+
+	if err := group.Validate(); err != nil {
+		t.Errorf("Validate returned an error for a well-formed group: %v", err)
+	}
+
+	empty := Group{ID: 2}
+	if err := empty.Validate(); err == nil {
+		t.Errorf("expected an error for a group with no elements")
+	}
+}
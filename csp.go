@@ -0,0 +1,81 @@
+package cvc
+
+import (
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// CipherServiceProvider abstracts the key generation, key arithmetic, and
+// signing operations Config's F0/F1 pipeline needs, modeled on Hyperledger
+// Fabric's BCCSP and Bytom's CSP: callers program against PrivateKey and
+// PublicKey handles - here, jwk.Key - instead of a hard-coded curve, so a
+// deployment can swap in a different curve, or a hardware-backed
+// implementation, without forking the module.
+type CipherServiceProvider interface {
+	// GenerateSecretKey generates a fresh private key on this provider's curve.
+	GenerateSecretKey() (jwk.Key, error)
+	// AddSecretKeys adds two private keys via scalar addition modulo the curve order.
+	AddSecretKeys(key1, key2 jwk.Key) (jwk.Key, error)
+	// AddPublicKeys adds two public keys via elliptic curve point addition.
+	AddPublicKeys(key1, key2 jwk.Key, opts ...KeyOption) (jwk.Key, error)
+	// DeriveSecretKey derives a child secret key from master key material using hash-to-field.
+	DeriveSecretKey(master jwk.Key, context, dst []byte, opts ...KeyOption) (jwk.Key, error)
+	// Sign signs payload with sk and returns a JWS.
+	Sign(payload []byte, sk jwk.Key) ([]byte, error)
+	// Verify verifies a compact or JSON-serialized JWS against pk and returns the verified payload.
+	Verify(jwsMessage []byte, pk jwk.Key) ([]byte, error)
+}
+
+// ECDSACipherServiceProvider is a CipherServiceProvider backed directly by
+// this module's in-memory ECDSA key generation, derivation, and point
+// arithmetic. Curve selects which curve GenerateSecretKey uses; the other
+// operations infer their curve from the keys they're given, so e.g.
+// AddPublicKeys(VcPub, WpPub) works the same regardless of Curve as long as
+// VcPub and WpPub are on the same curve.
+type ECDSACipherServiceProvider struct {
+	Curve internal.Curve
+}
+
+// NewP256CipherServiceProvider returns the module's original CSP: NIST
+// P-256 throughout.
+func NewP256CipherServiceProvider() *ECDSACipherServiceProvider {
+	return &ECDSACipherServiceProvider{Curve: internal.CurveP256}
+}
+
+// NewP384CipherServiceProvider returns a CSP generating NIST P-384 keys,
+// for deployments whose wallet/HSM integration expects that curve.
+func NewP384CipherServiceProvider() *ECDSACipherServiceProvider {
+	return &ECDSACipherServiceProvider{Curve: internal.CurveP384}
+}
+
+// NewSecp256k1CipherServiceProvider returns a CSP generating secp256k1
+// keys, for deployments whose wallet/HSM integration expects that curve.
+func NewSecp256k1CipherServiceProvider() *ECDSACipherServiceProvider {
+	return &ECDSACipherServiceProvider{Curve: internal.CurveSecp256k1}
+}
+
+func (p *ECDSACipherServiceProvider) GenerateSecretKey() (jwk.Key, error) {
+	return GenerateSecretKeyForCurve(p.Curve)
+}
+
+func (p *ECDSACipherServiceProvider) AddSecretKeys(key1, key2 jwk.Key) (jwk.Key, error) {
+	return AddSecretKeys(key1, key2)
+}
+
+func (p *ECDSACipherServiceProvider) AddPublicKeys(key1, key2 jwk.Key, opts ...KeyOption) (jwk.Key, error) {
+	return AddPublicKeys(key1, key2, opts...)
+}
+
+func (p *ECDSACipherServiceProvider) DeriveSecretKey(master jwk.Key, context, dst []byte, opts ...KeyOption) (jwk.Key, error) {
+	return DeriveSecretKey(master, context, dst, opts...)
+}
+
+func (p *ECDSACipherServiceProvider) Sign(payload []byte, sk jwk.Key) ([]byte, error) {
+	return SignWithSecretKey(payload, sk, "")
+}
+
+func (p *ECDSACipherServiceProvider) Verify(jwsMessage []byte, pk jwk.Key) ([]byte, error) {
+	return VerifyWithPublicKey(jwsMessage, pk)
+}
+
+var _ CipherServiceProvider = (*ECDSACipherServiceProvider)(nil)
@@ -0,0 +1,116 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func TestECDSACipherServiceProviderGenerateSecretKeyUsesConfiguredCurve(t *testing.T) {
+	cases := []struct {
+		name     string
+		provider *ECDSACipherServiceProvider
+		curve    internal.Curve
+	}{
+		{"P256", NewP256CipherServiceProvider(), internal.CurveP256},
+		{"P384", NewP384CipherServiceProvider(), internal.CurveP384},
+		{"Secp256k1", NewSecp256k1CipherServiceProvider(), internal.CurveSecp256k1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := tc.provider.GenerateSecretKey()
+			if err != nil {
+				t.Fatalf("GenerateSecretKey returned an error: %v", err)
+			}
+
+			var privateKey ecdsa.PrivateKey
+			if err := key.Raw(&privateKey); err != nil {
+				t.Fatalf("failed to extract generated key: %v", err)
+			}
+
+			curve, err := curveFromEllipticCurve(privateKey.Curve)
+			if err != nil {
+				t.Fatalf("failed to identify curve of generated key: %v", err)
+			}
+			if curve != tc.curve {
+				t.Errorf("curve = %v, want %v", curve, tc.curve)
+			}
+		})
+	}
+}
+
+func TestECDSACipherServiceProviderConfirmationKeyAcrossCurves(t *testing.T) {
+	cases := []struct {
+		name     string
+		provider *ECDSACipherServiceProvider
+	}{
+		{"P256", NewP256CipherServiceProvider()},
+		{"P384", NewP384CipherServiceProvider()},
+		{"Secp256k1", NewSecp256k1CipherServiceProvider()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			vcSecretKey, err := tc.provider.GenerateSecretKey()
+			if err != nil {
+				t.Fatalf("GenerateSecretKey returned an error: %v", err)
+			}
+			wpSecretKey, err := tc.provider.GenerateSecretKey()
+			if err != nil {
+				t.Fatalf("GenerateSecretKey returned an error: %v", err)
+			}
+
+			vcPubKey, err := vcSecretKey.PublicKey()
+			if err != nil {
+				t.Fatalf("failed to derive VC public key: %v", err)
+			}
+			wpPubKey, err := wpSecretKey.PublicKey()
+			if err != nil {
+				t.Fatalf("failed to derive WP public key: %v", err)
+			}
+
+			if _, err := tc.provider.AddPublicKeys(vcPubKey, wpPubKey); err != nil {
+				t.Errorf("AddPublicKeys returned an error: %v", err)
+			}
+		})
+	}
+}
+
+// staticMasterKeyStore is a MasterKeyStore that always returns the same key.
+type staticMasterKeyStore struct {
+	key jwk.Key
+}
+
+func (s staticMasterKeyStore) GetMasterKey() (jwk.Key, error) {
+	return s.key, nil
+}
+
+func TestConfigF0F1WithNonDefaultCSP(t *testing.T) {
+	masterKey, err := GenerateSecretKeyForCurve(internal.CurveP384)
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	config := &Config{
+		MasterKeyStore: staticMasterKeyStore{key: masterKey},
+		CredentialKey:  []byte("CVC-CSP-TEST-DST-v1.0"),
+		CSP:            NewP384CipherServiceProvider(),
+	}
+
+	userMap, err := config.F0(map[string]string{"user-1": "user-1@example.com"})
+	if err != nil {
+		t.Fatalf("F0 returned an error: %v", err)
+	}
+
+	vcPayload := map[string]interface{}{}
+	if err := config.F1("user-1", vcPayload, userMap); err != nil {
+		t.Fatalf("F1 returned an error: %v", err)
+	}
+
+	if _, ok := vcPayload["cnf"]; !ok {
+		t.Errorf("expected F1 to add a cnf claim to the VC payload")
+	}
+}
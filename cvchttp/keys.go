@@ -0,0 +1,104 @@
+package cvchttp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// maxContextSize and maxDSTSize mirror the limits DeriveSecretKey itself
+// enforces (see its ErrorCases test subtest): requests whose decoded
+// context or DST is larger are rejected here, before any derivation runs,
+// rather than relying solely on the error DeriveSecretKey would eventually
+// return.
+const (
+	maxContextSize = 2048
+	maxDSTSize     = 256
+)
+
+type deriveKeyRequest struct {
+	// Context and DST are base64-encoded, since both are arbitrary bytes.
+	Context          string  `json:"context"`
+	DST              string  `json:"dst"`
+	MasterKeyVersion *uint32 `json:"master_key_version,omitempty"`
+}
+
+type deriveKeyResponse struct {
+	PublicKey  json.RawMessage `json:"public_key"`
+	PrivateKey json.RawMessage `json:"private_key,omitempty"`
+}
+
+// handleDeriveKey handles POST /v1/keys/derive: it derives a child key
+// under s.Ring's current (or, if MasterKeyVersion is set, a historical)
+// master key version, returning the derived public key and, only if
+// s.AuthorizePrivateKey authorizes the request, the derived private key.
+func (s *Server) handleDeriveKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	var req deriveKeyRequest
+	if err := decodeBody(w, r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	context, err := base64.StdEncoding.DecodeString(req.Context)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("context is not valid base64: %w", err))
+		return
+	}
+	if len(context) > maxContextSize {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("context exceeds the maximum size of %d bytes", maxContextSize))
+		return
+	}
+
+	dst, err := base64.StdEncoding.DecodeString(req.DST)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("dst is not valid base64: %w", err))
+		return
+	}
+	if len(dst) > maxDSTSize {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("dst exceeds the maximum size of %d bytes", maxDSTSize))
+		return
+	}
+
+	var derived jwk.Key
+	if req.MasterKeyVersion != nil {
+		derived, err = s.Ring.DeriveSecretKeyAt(*req.MasterKeyVersion, context, dst)
+	} else {
+		derived, err = s.Ring.DeriveSecretKey(context, dst)
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	publicKey, err := derived.PublicKey()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to derive public key: %w", err))
+		return
+	}
+
+	publicJSON, err := json.Marshal(publicKey)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal public key: %w", err))
+		return
+	}
+
+	resp := deriveKeyResponse{PublicKey: publicJSON}
+	if s.AuthorizePrivateKey != nil && s.AuthorizePrivateKey(r) {
+		privateJSON, err := json.Marshal(derived)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to marshal private key: %w", err))
+			return
+		}
+		resp.PrivateKey = privateJSON
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,44 @@
+package cvchttp
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// supportedLanguageNames lists the ISO 639-1 codes (these double as BCP 47
+// base-language subtags, so they work directly with builder.NewLanguage)
+// this deployment's presentations commonly ship in, alongside their English
+// names.
+var supportedLanguageNames = map[string]string{
+	"en": "English",
+	"sl": "Slovenian",
+	"de": "German",
+	"fr": "French",
+	"it": "Italian",
+	"es": "Spanish",
+	"pt": "Portuguese",
+	"nl": "Dutch",
+}
+
+type languageInfo struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// handleListLanguages handles GET /v1/languages, returning the supported
+// ISO 639-1 codes and their English names.
+func (s *Server) handleListLanguages(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	infos := make([]languageInfo, 0, len(supportedLanguageNames))
+	for code, name := range supportedLanguageNames {
+		infos = append(infos, languageInfo{Code: code, Name: name})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Code < infos[j].Code })
+
+	writeJSON(w, http.StatusOK, infos)
+}
@@ -0,0 +1,48 @@
+package cvchttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/MyNextID/cvc-go/builder"
+)
+
+// handleCreatePresentation handles POST /v1/presentations: it parses the
+// request body as a declarative presentation document - JSON or YAML, per
+// the same Content-Type rules as decodeBody - and returns the canonical
+// JSON builder.Presentation.Create produces.
+func (s *Server) handleCreatePresentation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read request body: %w", err))
+		return
+	}
+
+	var presentation *builder.Presentation
+	if isYAMLContentType(r.Header.Get("Content-Type")) {
+		presentation, err = builder.LoadPresentationYAML(data)
+	} else {
+		presentation, err = builder.LoadPresentationJSON(data)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := presentation.Create()
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(output)
+}
@@ -0,0 +1,93 @@
+// Package cvchttp exposes this module's key derivation and presentation
+// building over HTTP/JSON, for deployments that want those operations
+// behind a service boundary instead of linking the cvc/builder packages
+// directly.
+package cvchttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/MyNextID/cvc-go"
+	"github.com/ghodss/yaml"
+)
+
+// maxRequestBodyBytes caps the raw request body cvchttp will read, well
+// above what a base64-encoded context (<=2048 bytes) and DST (<=256 bytes)
+// ever need, to stop an oversized body from being fully buffered before
+// DeriveSecretKey's own size checks ever run.
+const maxRequestBodyBytes = 1 << 16 // 64 KiB
+
+// Server exposes cvc/builder operations over HTTP. Ring backs
+// POST /v1/keys/derive. AuthorizePrivateKey, if set, is consulted on every
+// derive request to decide whether the response may include the derived
+// private key; a nil AuthorizePrivateKey means the endpoint never returns
+// private key material.
+type Server struct {
+	Ring                *cvc.KeyRing
+	AuthorizePrivateKey func(r *http.Request) bool
+}
+
+// NewServer returns a Server deriving keys from ring.
+func NewServer(ring *cvc.KeyRing) *Server {
+	return &Server{Ring: ring}
+}
+
+// Handler returns the http.Handler routing this server's endpoints.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/keys/derive", s.handleDeriveKey)
+	mux.HandleFunc("/v1/presentations", s.handleCreatePresentation)
+	mux.HandleFunc("/v1/languages", s.handleListLanguages)
+	return mux
+}
+
+// errorResponse is the structured body every non-2xx response carries.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// decodeBody reads r's body - capped at maxRequestBodyBytes - and unmarshals
+// it into v. It accepts both application/json and application/yaml (or
+// application/x-yaml) request bodies, converting YAML to JSON first and
+// reusing a single json.Unmarshal path so the two formats can't diverge.
+// An empty or missing Content-Type is treated as JSON.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	if isYAMLContentType(r.Header.Get("Content-Type")) {
+		data, err = yaml.YAMLToJSON(data)
+		if err != nil {
+			return fmt.Errorf("failed to convert YAML request body to JSON: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to unmarshal request body: %w", err)
+	}
+
+	return nil
+}
+
+func isYAMLContentType(contentType string) bool {
+	mediaType := strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	return mediaType == "application/yaml" || mediaType == "application/x-yaml" || mediaType == "text/yaml"
+}
@@ -0,0 +1,218 @@
+package cvchttp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/MyNextID/cvc-go"
+	"github.com/MyNextID/cvc-go/internal"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	ring, err := cvc.NewKeyRing(internal.CurveP256)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned an error: %v", err)
+	}
+	return NewServer(ring)
+}
+
+func deriveRequestBody(t *testing.T, context, dst []byte) []byte {
+	t.Helper()
+	body, err := json.Marshal(deriveKeyRequest{
+		Context: base64.StdEncoding.EncodeToString(context),
+		DST:     base64.StdEncoding.EncodeToString(dst),
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+	return body
+}
+
+func TestHandleDeriveKey(t *testing.T) {
+	server := newTestServer(t)
+	handler := server.Handler()
+
+	t.Run("ValidDerivation", func(t *testing.T) {
+		body := deriveRequestBody(t, []byte("test-context-for-key-derivation"), []byte("CVC-TEST-DST-v1.0"))
+		req := httptest.NewRequest(http.MethodPost, "/v1/keys/derive", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusOK, rec.Body.String())
+		}
+
+		var resp deriveKeyResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.PublicKey) == 0 {
+			t.Errorf("expected a public key in the response")
+		}
+		if len(resp.PrivateKey) != 0 {
+			t.Errorf("expected no private key without an AuthorizePrivateKey hook")
+		}
+	})
+
+	t.Run("PrivateKeyRequiresAuthorization", func(t *testing.T) {
+		server.AuthorizePrivateKey = func(r *http.Request) bool { return true }
+		defer func() { server.AuthorizePrivateKey = nil }()
+
+		body := deriveRequestBody(t, []byte("test-context-for-key-derivation"), []byte("CVC-TEST-DST-v1.0"))
+		req := httptest.NewRequest(http.MethodPost, "/v1/keys/derive", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		var resp deriveKeyResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		if len(resp.PrivateKey) == 0 {
+			t.Errorf("expected a private key once AuthorizePrivateKey authorizes the request")
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		body := deriveRequestBody(t, []byte("deterministic-test"), []byte("CVC-DETERMINISTIC-DST-v1.0"))
+
+		var responses [2]deriveKeyResponse
+		for i := range responses {
+			req := httptest.NewRequest(http.MethodPost, "/v1/keys/derive", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if err := json.Unmarshal(rec.Body.Bytes(), &responses[i]); err != nil {
+				t.Fatalf("failed to unmarshal response %d: %v", i, err)
+			}
+		}
+
+		if !bytes.Equal(responses[0].PublicKey, responses[1].PublicKey) {
+			t.Errorf("two derivations of the same context/dst produced different public keys")
+		}
+	})
+
+	t.Run("ErrorCases", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			context []byte
+			dst     []byte
+		}{
+			{"EmptyContext", []byte{}, []byte("CVC-ERROR-TEST-DST-v1.0")},
+			{"EmptyDST", []byte("valid-context"), []byte{}},
+			{"OversizedContext", bytes.Repeat([]byte{0x41}, maxContextSize+1), []byte("CVC-ERROR-TEST-DST-v1.0")},
+			{"OversizedDST", []byte("valid-context"), bytes.Repeat([]byte{0x41}, maxDSTSize+1)},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				body := deriveRequestBody(t, tc.context, tc.dst)
+				req := httptest.NewRequest(http.MethodPost, "/v1/keys/derive", bytes.NewReader(body))
+				req.Header.Set("Content-Type", "application/json")
+				rec := httptest.NewRecorder()
+
+				handler.ServeHTTP(rec, req)
+
+				if rec.Code < 400 {
+					t.Errorf("status = %d, want an error status", rec.Code)
+				}
+			})
+		}
+	})
+
+	t.Run("RejectsGET", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/keys/derive", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestHandleCreatePresentationAcceptsJSONAndYAML(t *testing.T) {
+	server := newTestServer(t)
+	handler := server.Handler()
+
+	jsonDoc := []byte(`{
+		"languages": ["en"],
+		"groups": [{"id": 1, "elements": [{"title": {"en": "Full name"}, "value": "/credentialSubject/fullName"}]}]
+	}`)
+	yamlDoc := []byte(`
+languages:
+  - en
+groups:
+  - id: 1
+    elements:
+      - title:
+          en: Full name
+        value: /credentialSubject/fullName
+`)
+
+	cases := []struct {
+		name        string
+		body        []byte
+		contentType string
+	}{
+		{"JSON", jsonDoc, "application/json"},
+		{"YAML", yamlDoc, "application/yaml"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v1/presentations", bytes.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d; body: %s", rec.Code, http.StatusOK, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), "credentialSubject") {
+				t.Errorf("response body = %s, want it to contain the element value", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleListLanguages(t *testing.T) {
+	server := newTestServer(t)
+	handler := server.Handler()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/languages", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var infos []languageInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &infos); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if len(infos) == 0 {
+		t.Fatalf("expected at least one supported language")
+	}
+
+	found := false
+	for _, info := range infos {
+		if info.Code == "en" && info.Name == "English" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected English to be among the supported languages, got %+v", infos)
+	}
+}
@@ -0,0 +1,63 @@
+package cvc
+
+import (
+	"fmt"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// DeriveSecretKeys derives one secret key per context from master key
+// material, packing every context into a single hash-to-field call (RFC
+// 9380 §5.3) instead of paying the expand_message_xmd setup cost once per
+// context. It is bit-for-bit equivalent to calling DeriveSecretKey in a
+// loop with the same dst/contexts. Unlike DeriveSecretKeysBatch, a single
+// bad context fails the whole call rather than reporting a per-context
+// error.
+func DeriveSecretKeys(master jwk.Key, contexts [][]byte, dst []byte) ([]jwk.Key, error) {
+	keys, errs, err := DeriveSecretKeysBatch(master, contexts, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, keyErr := range errs {
+		if keyErr != nil {
+			return nil, internal.WrapError(keyErr, fmt.Sprintf("context %d failed to derive", i))
+		}
+	}
+
+	return keys, nil
+}
+
+// DerivePublicKeys derives the same per-context scalars as DeriveSecretKeys
+// but returns each one added to master's public key (G*d_i + masterPub)
+// instead of the bare secret, so a verifier can reconstruct per-attribute
+// public keys without ever learning the derived secrets.
+func DerivePublicKeys(master jwk.Key, contexts [][]byte, dst []byte) ([]jwk.Key, error) {
+	derivedKeys, err := DeriveSecretKeys(master, contexts, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	masterPublic, err := master.PublicKey()
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrJWKExtraction, "failed to derive master public key")
+	}
+
+	publicKeys := make([]jwk.Key, len(derivedKeys))
+	for i, derivedKey := range derivedKeys {
+		derivedPublic, err := derivedKey.PublicKey()
+		if err != nil {
+			return nil, internal.WrapError(internal.ErrJWKExtraction, fmt.Sprintf("failed to derive public key for context %d", i))
+		}
+
+		combined, err := AddPublicKeys(masterPublic, derivedPublic)
+		if err != nil {
+			return nil, internal.WrapError(err, fmt.Sprintf("failed to combine public key for context %d", i))
+		}
+
+		publicKeys[i] = combined
+	}
+
+	return publicKeys, nil
+}
@@ -0,0 +1,104 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"testing"
+)
+
+func TestDeriveSecretKeysBatch(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+
+	dst := []byte("CVC-TEST-DST-v1.0")
+	contexts := make([][]byte, 5)
+	for i := range contexts {
+		contexts[i] = []byte(fmt.Sprintf("test-context-%d", i))
+	}
+
+	derivedKeys, errs, err := DeriveSecretKeysBatch(masterKey, contexts, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKeysBatch failed: %v", err)
+	}
+
+	if len(derivedKeys) != len(contexts) {
+		t.Fatalf("expected %d derived keys, got %d", len(contexts), len(derivedKeys))
+	}
+
+	for i, derivedKey := range derivedKeys {
+		if errs[i] != nil {
+			t.Fatalf("context %d failed to derive: %v", i, errs[i])
+		}
+
+		var privateKey ecdsa.PrivateKey
+		if err := derivedKey.Raw(&privateKey); err != nil {
+			t.Fatalf("failed to extract derived private key %d: %v", i, err)
+		}
+
+		if privateKey.D.Sign() == 0 {
+			t.Errorf("derived private key %d is zero", i)
+		}
+	}
+
+	// Batch derivation must match single-item derivation for the same inputs.
+	singleKey, err := DeriveSecretKey(masterKey, contexts[0], dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKey failed: %v", err)
+	}
+
+	var singlePrivate, batchPrivate ecdsa.PrivateKey
+	if err := singleKey.Raw(&singlePrivate); err != nil {
+		t.Fatalf("failed to extract single-derived private key: %v", err)
+	}
+	if err := derivedKeys[0].Raw(&batchPrivate); err != nil {
+		t.Fatalf("failed to extract batch-derived private key: %v", err)
+	}
+
+	if singlePrivate.D.Cmp(batchPrivate.D) != 0 {
+		t.Errorf("batch derivation for context 0 diverged from single derivation")
+	}
+}
+
+func TestDeriveSecretKeysBatchRejectsEmptyContexts(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+
+	if _, _, err := DeriveSecretKeysBatch(masterKey, nil, []byte("CVC-TEST-DST-v1.0")); err == nil {
+		t.Fatalf("expected an error for empty contexts")
+	}
+}
+
+func BenchmarkDeriveSecretKeysBatch(b *testing.B) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		b.Fatalf("Failed to generate master key: %v", err)
+	}
+
+	dst := []byte("CVC-TEST-DST-v1.0")
+	contexts := make([][]byte, 100)
+	for i := range contexts {
+		contexts[i] = []byte(fmt.Sprintf("bench-context-%d", i))
+	}
+
+	b.Run("Batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, _, err := DeriveSecretKeysBatch(masterKey, contexts, dst); err != nil {
+				b.Fatalf("DeriveSecretKeysBatch failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("OneAtATime", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, context := range contexts {
+				if _, err := DeriveSecretKey(masterKey, context, dst); err != nil {
+					b.Fatalf("DeriveSecretKey failed: %v", err)
+				}
+			}
+		}
+	})
+}
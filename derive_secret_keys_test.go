@@ -0,0 +1,110 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"testing"
+)
+
+func TestDeriveSecretKeysMatchesLoop(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+
+	dst := []byte("CVC-TEST-DST-v1.0")
+	contexts := make([][]byte, 4)
+	for i := range contexts {
+		contexts[i] = []byte(fmt.Sprintf("test-context-%d", i))
+	}
+
+	derivedKeys, err := DeriveSecretKeys(masterKey, contexts, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKeys failed: %v", err)
+	}
+	if len(derivedKeys) != len(contexts) {
+		t.Fatalf("expected %d derived keys, got %d", len(contexts), len(derivedKeys))
+	}
+
+	for i, context := range contexts {
+		singleKey, err := DeriveSecretKey(masterKey, context, dst)
+		if err != nil {
+			t.Fatalf("DeriveSecretKey failed: %v", err)
+		}
+
+		var single, batch ecdsa.PrivateKey
+		if err := singleKey.Raw(&single); err != nil {
+			t.Fatalf("failed to extract single-derived private key %d: %v", i, err)
+		}
+		if err := derivedKeys[i].Raw(&batch); err != nil {
+			t.Fatalf("failed to extract batch-derived private key %d: %v", i, err)
+		}
+
+		if single.D.Cmp(batch.D) != 0 {
+			t.Errorf("DeriveSecretKeys diverged from DeriveSecretKey for context %d", i)
+		}
+	}
+}
+
+func TestDerivePublicKeysMatchesSecretKeys(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+
+	dst := []byte("CVC-TEST-DST-v1.0")
+	contexts := [][]byte{[]byte("attribute-1"), []byte("attribute-2")}
+
+	secretKeys, err := DeriveSecretKeys(masterKey, contexts, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKeys failed: %v", err)
+	}
+
+	publicKeys, err := DerivePublicKeys(masterKey, contexts, dst)
+	if err != nil {
+		t.Fatalf("DerivePublicKeys failed: %v", err)
+	}
+	if len(publicKeys) != len(contexts) {
+		t.Fatalf("expected %d derived public keys, got %d", len(contexts), len(publicKeys))
+	}
+
+	masterPublic, err := masterKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive master public key: %v", err)
+	}
+
+	for i := range contexts {
+		secretPublic, err := secretKeys[i].PublicKey()
+		if err != nil {
+			t.Fatalf("failed to derive public key for context %d: %v", i, err)
+		}
+
+		want, err := AddPublicKeys(masterPublic, secretPublic)
+		if err != nil {
+			t.Fatalf("AddPublicKeys failed for context %d: %v", i, err)
+		}
+
+		var wantPub, gotPub ecdsa.PublicKey
+		if err := want.Raw(&wantPub); err != nil {
+			t.Fatalf("failed to extract expected public key %d: %v", i, err)
+		}
+		if err := publicKeys[i].Raw(&gotPub); err != nil {
+			t.Fatalf("failed to extract derived public key %d: %v", i, err)
+		}
+
+		if wantPub.X.Cmp(gotPub.X) != 0 || wantPub.Y.Cmp(gotPub.Y) != 0 {
+			t.Errorf("DerivePublicKeys[%d] does not match master pub + G*d", i)
+		}
+	}
+}
+
+func TestDeriveSecretKeysRejectsEmptyContexts(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("Failed to generate master key: %v", err)
+	}
+
+	if _, err := DeriveSecretKeys(masterKey, nil, []byte("CVC-TEST-DST-v1.0")); err == nil {
+		t.Fatalf("expected an error for empty contexts")
+	}
+}
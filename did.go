@@ -0,0 +1,123 @@
+package cvc
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// VerificationMethod is a W3C DID Document verification method expressed as
+// a JsonWebKey2020, see https://www.w3.org/TR/did-spec-registries/#jsonwebkey2020.
+type VerificationMethod struct {
+	ID           string                 `json:"id"`
+	Type         string                 `json:"type"`
+	Controller   string                 `json:"controller"`
+	PublicKeyJwk map[string]interface{} `json:"publicKeyJwk"`
+}
+
+// DIDDocument is a minimal W3C DID Document fragment carrying the
+// verification methods derived for a batch of wallet-provider keys.
+type DIDDocument struct {
+	Context            []string             `json:"@context"`
+	ID                 string               `json:"id"`
+	VerificationMethod []VerificationMethod `json:"verificationMethod"`
+	AssertionMethod    []string             `json:"assertionMethod"`
+	Authentication     []string             `json:"authentication"`
+}
+
+// GenerateVerificationMethods converts a map of derived public keys (as
+// produced by ProviderConfig.GeneratePublicKeys) into JsonWebKey2020
+// verification methods under the given controller DID. Each method's
+// fragment identifier is the key's RFC 7638 JWK thumbprint, so consumers
+// can reference a given derived key deterministically.
+func (c *ProviderConfig) GenerateVerificationMethods(keyMap map[string]KeyData) ([]VerificationMethod, error) {
+	if c.ControllerDID == "" {
+		return nil, fmt.Errorf("ProviderConfig.ControllerDID must be set to generate verification methods")
+	}
+
+	methods := make([]VerificationMethod, 0, len(keyMap))
+	for _, keyData := range keyMap {
+		pubKey, err := jwk.ParseKey(keyData.WpPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key for key id %s: %w", keyData.KeyID, err)
+		}
+
+		thumbprint, err := pubKey.Thumbprint(crypto.SHA256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute JWK thumbprint for key id %s: %w", keyData.KeyID, err)
+		}
+		fragment := base64.RawURLEncoding.EncodeToString(thumbprint)
+
+		jwkBytes, err := json.Marshal(pubKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal public key for key id %s: %w", keyData.KeyID, err)
+		}
+		var jwkMap map[string]interface{}
+		if err := json.Unmarshal(jwkBytes, &jwkMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal public key for key id %s: %w", keyData.KeyID, err)
+		}
+
+		methods = append(methods, VerificationMethod{
+			ID:           fmt.Sprintf("%s#%s", c.ControllerDID, fragment),
+			Type:         "JsonWebKey2020",
+			Controller:   c.ControllerDID,
+			PublicKeyJwk: jwkMap,
+		})
+	}
+
+	return methods, nil
+}
+
+// GenerateDIDDocument derives public keys for the given hash batch (the
+// same request shape as GeneratePublicKeys) and publishes them as a W3C DID
+// Document fragment, with each key expressed as a JsonWebKey2020
+// verification method referenced from both assertionMethod and
+// authentication. It rejects a ProviderConfig with EncryptTo set: a DID
+// document is meant to be published openly, and GeneratePublicKeys would
+// otherwise hand it an ECIES-sealed payload it can't unmarshal as a key map.
+func (c *ProviderConfig) GenerateDIDDocument(requestJson []byte) ([]byte, error) {
+	if c.ControllerDID == "" {
+		return nil, fmt.Errorf("ProviderConfig.ControllerDID must be set to generate a DID document")
+	}
+	if c.EncryptTo != nil {
+		return nil, fmt.Errorf("ProviderConfig.EncryptTo must not be set to generate a DID document: a DID document is published, not sealed to a recipient")
+	}
+
+	keyMapBytes, err := c.GeneratePublicKeys(requestJson)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate public keys: %w", err)
+	}
+
+	var keyMap map[string]KeyData
+	if err := json.Unmarshal(keyMapBytes, &keyMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal generated key map: %w", err)
+	}
+
+	methods, err := c.GenerateVerificationMethods(keyMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate verification methods: %w", err)
+	}
+
+	methodIDs := make([]string, len(methods))
+	for i, method := range methods {
+		methodIDs[i] = method.ID
+	}
+
+	doc := DIDDocument{
+		Context:            []string{"https://www.w3.org/ns/did/v1", "https://w3id.org/security/suites/jws-2020/v1"},
+		ID:                 c.ControllerDID,
+		VerificationMethod: methods,
+		AssertionMethod:    methodIDs,
+		Authentication:     methodIDs,
+	}
+
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal DID document: %w", err)
+	}
+
+	return docBytes, nil
+}
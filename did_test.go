@@ -0,0 +1,85 @@
+package cvc
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateDIDDocument(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	provider := &ProviderConfig{
+		MasterSecretKey: masterKey,
+		Dst:             "cvc-provider-v1",
+		ControllerDID:   "did:web:example.com",
+	}
+
+	requestJSON, err := json.Marshal([]string{"hash-one", "hash-two"})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	docBytes, err := provider.GenerateDIDDocument(requestJSON)
+	if err != nil {
+		t.Fatalf("GenerateDIDDocument returned an error: %v", err)
+	}
+
+	var doc DIDDocument
+	if err := json.Unmarshal(docBytes, &doc); err != nil {
+		t.Fatalf("failed to unmarshal DID document: %v", err)
+	}
+
+	if doc.ID != provider.ControllerDID {
+		t.Errorf("expected DID document id %q, got %q", provider.ControllerDID, doc.ID)
+	}
+
+	if len(doc.VerificationMethod) != 2 {
+		t.Fatalf("expected 2 verification methods, got %d", len(doc.VerificationMethod))
+	}
+
+	for _, method := range doc.VerificationMethod {
+		if method.Type != "JsonWebKey2020" {
+			t.Errorf("expected verification method type JsonWebKey2020, got %s", method.Type)
+		}
+		if method.PublicKeyJwk == nil {
+			t.Errorf("expected publicKeyJwk to be set for %s", method.ID)
+		}
+	}
+}
+
+func TestGenerateDIDDocumentRequiresController(t *testing.T) {
+	provider := &ProviderConfig{}
+
+	if _, err := provider.GenerateDIDDocument([]byte(`["hash"]`)); err == nil {
+		t.Fatalf("expected an error when ControllerDID is unset")
+	}
+}
+
+func TestGenerateDIDDocumentRejectsEncryptTo(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+	recipientKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate recipient key: %v", err)
+	}
+	recipientPub, err := recipientKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive recipient public key: %v", err)
+	}
+
+	provider := &ProviderConfig{
+		MasterSecretKey: masterKey,
+		Dst:             "cvc-provider-v1",
+		ControllerDID:   "did:web:example.com",
+		EncryptTo:       recipientPub,
+	}
+
+	if _, err := provider.GenerateDIDDocument([]byte(`["hash"]`)); err == nil {
+		t.Fatalf("expected an error when EncryptTo is set")
+	}
+}
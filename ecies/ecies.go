@@ -0,0 +1,157 @@
+// Package ecies implements ECIES (Elliptic Curve Integrated Encryption
+// Scheme) hybrid encryption over the curves this module supports, so master
+// key material can be sealed to a recipient at rest or in transit.
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+var (
+	// ErrInvalidKey is returned when a recipient or ephemeral key cannot be
+	// used for ECIES (wrong type, or an off-curve point).
+	ErrInvalidKey = errors.New("ecies: invalid key")
+	// ErrAuthentication is returned when the HMAC tag does not match,
+	// meaning the payload was tampered with or the wrong key was used.
+	ErrAuthentication = errors.New("ecies: authentication failed")
+)
+
+// Payload is the wire format produced by Encrypt: an ephemeral public key,
+// the AES-CTR IV, the ciphertext, and an HMAC-SHA256 tag over IV||ciphertext.
+type Payload struct {
+	EphemeralPublicKey jwk.Key `json:"ephemeral_public_key"`
+	IV                 []byte  `json:"iv"`
+	Ciphertext         []byte  `json:"ciphertext"`
+	MAC                []byte  `json:"mac"`
+}
+
+// Encrypt seals plaintext to recipientJWK (an ECDSA public key) using ECIES:
+// an ephemeral EC keypair on the recipient's curve, ECDH to a shared secret,
+// a concatenation KDF (SP 800-56A, SHA-256) to derive a 256-bit AES key and a
+// 256-bit HMAC key, AES-CTR encryption, and an HMAC-SHA256 tag over
+// IV||ciphertext.
+func Encrypt(recipientJWK jwk.Key, plaintext []byte) (*Payload, error) {
+	var recipientPub ecdsa.PublicKey
+	if err := recipientJWK.Raw(&recipientPub); err != nil {
+		return nil, fmt.Errorf("%w: recipient key is not an ECDSA public key: %s", ErrInvalidKey, err)
+	}
+
+	// Reject an off-curve recipient point before it ever reaches ECDH, to
+	// guard against invalid-curve attacks.
+	if !recipientPub.Curve.IsOnCurve(recipientPub.X, recipientPub.Y) {
+		return nil, fmt.Errorf("%w: recipient point is not on curve", ErrInvalidKey)
+	}
+
+	ephemeralPriv, err := ecdsa.GenerateKey(recipientPub.Curve, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("ecies: failed to generate ephemeral key: %w", err)
+	}
+
+	sharedX, _ := recipientPub.Curve.ScalarMult(recipientPub.X, recipientPub.Y, ephemeralPriv.D.Bytes())
+
+	aesKey, hmacKey := deriveKeys(sharedX.Bytes())
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("ecies: failed to generate iv: %w", err)
+	}
+
+	ciphertext, err := xorKeyStream(aesKey, iv, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralPubJWK, err := jwk.FromRaw(&ephemeralPriv.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("ecies: failed to convert ephemeral key to JWK: %w", err)
+	}
+
+	return &Payload{
+		EphemeralPublicKey: ephemeralPubJWK,
+		IV:                 iv,
+		Ciphertext:         ciphertext,
+		MAC:                tag(hmacKey, iv, ciphertext),
+	}, nil
+}
+
+// Decrypt opens a Payload produced by Encrypt using the recipient's private key.
+func Decrypt(recipientPrivateJWK jwk.Key, payload *Payload) ([]byte, error) {
+	var recipientPriv ecdsa.PrivateKey
+	if err := recipientPrivateJWK.Raw(&recipientPriv); err != nil {
+		return nil, fmt.Errorf("%w: recipient key is not an ECDSA private key: %s", ErrInvalidKey, err)
+	}
+
+	var ephemeralPub ecdsa.PublicKey
+	if err := payload.EphemeralPublicKey.Raw(&ephemeralPub); err != nil {
+		return nil, fmt.Errorf("%w: ephemeral key is not an ECDSA public key: %s", ErrInvalidKey, err)
+	}
+
+	if !recipientPriv.Curve.IsOnCurve(ephemeralPub.X, ephemeralPub.Y) {
+		return nil, fmt.Errorf("%w: ephemeral point is not on curve", ErrInvalidKey)
+	}
+
+	sharedX, _ := recipientPriv.Curve.ScalarMult(ephemeralPub.X, ephemeralPub.Y, recipientPriv.D.Bytes())
+
+	aesKey, hmacKey := deriveKeys(sharedX.Bytes())
+
+	if !hmac.Equal(tag(hmacKey, payload.IV, payload.Ciphertext), payload.MAC) {
+		return nil, ErrAuthentication
+	}
+
+	return xorKeyStream(aesKey, payload.IV, payload.Ciphertext)
+}
+
+// MarshalJSON marshals a Payload as a transport-friendly envelope; jwk.Key
+// already marshals to standard JWK JSON. Payload has no UnmarshalJSON, so
+// encoding/json cannot decode the envelope back into its jwk.Key field.
+func (p *Payload) MarshalJSON() ([]byte, error) {
+	type alias Payload
+	return json.Marshal((*alias)(p))
+}
+
+func xorKeyStream(aesKey, iv, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("ecies: failed to create AES cipher: %w", err)
+	}
+
+	output := make([]byte, len(input))
+	cipher.NewCTR(block, iv).XORKeyStream(output, input)
+	return output, nil
+}
+
+// deriveKeys expands an ECDH shared secret into a 32-byte AES-256 key and a
+// 32-byte HMAC key using the NIST SP 800-56A concatenation KDF instantiated
+// with SHA-256.
+func deriveKeys(sharedSecret []byte) (aesKey, hmacKey []byte) {
+	okm := concatKDF(sharedSecret, 64)
+	return okm[:32], okm[32:]
+}
+
+func concatKDF(secret []byte, length int) []byte {
+	output := make([]byte, 0, length)
+	for counter := uint32(1); len(output) < length; counter++ {
+		h := sha256.New()
+		h.Write([]byte{byte(counter >> 24), byte(counter >> 16), byte(counter >> 8), byte(counter)})
+		h.Write(secret)
+		output = h.Sum(output)
+	}
+	return output[:length]
+}
+
+func tag(hmacKey, iv, ciphertext []byte) []byte {
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(iv)
+	mac.Write(ciphertext)
+	return mac.Sum(nil)
+}
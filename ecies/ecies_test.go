@@ -0,0 +1,72 @@
+package ecies
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	recipientJWK, err := generateTestKey(t)
+	if err != nil {
+		t.Fatalf("failed to generate recipient key: %v", err)
+	}
+
+	recipientPub, err := recipientJWK.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive recipient public key: %v", err)
+	}
+
+	plaintext := []byte("cvc master key material")
+
+	payload, err := Encrypt(recipientPub, plaintext)
+	if err != nil {
+		t.Fatalf("Encrypt returned an error: %v", err)
+	}
+
+	decrypted, err := Decrypt(recipientJWK, payload)
+	if err != nil {
+		t.Fatalf("Decrypt returned an error: %v", err)
+	}
+
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Fatalf("decrypted plaintext %q does not match original %q", decrypted, plaintext)
+	}
+}
+
+func TestDecryptRejectsTamperedCiphertext(t *testing.T) {
+	recipientJWK, err := generateTestKey(t)
+	if err != nil {
+		t.Fatalf("failed to generate recipient key: %v", err)
+	}
+	recipientPub, err := recipientJWK.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive recipient public key: %v", err)
+	}
+
+	payload, err := Encrypt(recipientPub, []byte("sensitive"))
+	if err != nil {
+		t.Fatalf("Encrypt returned an error: %v", err)
+	}
+
+	payload.Ciphertext[0] ^= 0xFF
+
+	if _, err := Decrypt(recipientJWK, payload); err == nil {
+		t.Fatalf("expected Decrypt to reject a tampered ciphertext")
+	}
+}
+
+func generateTestKey(t *testing.T) (jwk.Key, error) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return jwk.FromRaw(privateKey)
+}
@@ -1,82 +1,217 @@
 package cvc
 
 import (
-	"crypto/ecdsa"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
+	"runtime"
+	"sort"
+	"sync"
 
 	"github.com/MyNextID/cvc-go/pkg"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 )
 
-// F0 generates wallet provider public keys for a map of users
+// Config is this module's original F0/F1 key-confirmation pipeline,
+// predating the provider/issuer split in ProviderConfig and IssuerConfig.
+// CSP lets callers swap in a different curve - or a different
+// CipherServiceProvider implementation entirely - without editing F0 or F1
+// themselves.
+type Config struct {
+	MasterKeyStore MasterKeyStore
+	// CredentialKey is the domain separation tag used to derive each
+	// user's wallet provider key pair in F0.
+	CredentialKey []byte
+	// CSP performs this Config's key generation, derivation, and
+	// confirmation-key arithmetic. A nil CSP falls back to a P-256
+	// in-memory provider, preserving this module's original behavior.
+	// Only consulted when Curve is CurveP256.
+	CSP CipherServiceProvider
+	// Curve selects which group F0/F1 derive and combine keys over. The
+	// zero value, CurveP256, routes through CSP unchanged; CurveX25519
+	// routes through the DeriveX25519SecretKey/AddX25519PublicKeys family
+	// in x25519.go instead.
+	Curve Curve
+	// F0Concurrency is the number of worker goroutines F0 fans its
+	// per-user derivation work out to. Zero or negative uses
+	// runtime.GOMAXPROCS(0).
+	F0Concurrency int
+}
+
+// f0Concurrency returns c.F0Concurrency, falling back to
+// runtime.GOMAXPROCS(0) when it isn't set.
+func (c *Config) f0Concurrency() int {
+	if c.F0Concurrency > 0 {
+		return c.F0Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// csp returns c.CSP, falling back to a P-256 ECDSACipherServiceProvider
+// when none has been configured.
+func (c *Config) csp() CipherServiceProvider {
+	if c.CSP != nil {
+		return c.CSP
+	}
+	return NewP256CipherServiceProvider()
+}
+
+// f0Job is one unit of F0's worker-pool fan-out: a single user's uuid/email
+// pair, tagged with its position in the sorted uuid order so results can be
+// reassembled deterministically regardless of which worker handles it.
+type f0Job struct {
+	index int
+	uuid  string
+	email string
+}
+
+// f0Result is a completed (or skipped) f0Job. A zero-value Data/Err pair
+// means the job was never attempted because an earlier failure had already
+// cancelled the run.
+type f0Result struct {
+	uuid string
+	data *UserData
+	err  error
+}
+
+// F0 generates wallet provider public keys for a map of users. Work is
+// fanned out over F0Concurrency worker goroutines, since for realistic
+// batches (10k+ recipients) each user's derivation - salt generation, a
+// hash, and an HKDF-style DeriveSecretKey call - is the dominant cost and
+// independent across users.
+//
+// On the first failure, remaining unstarted jobs are cancelled via
+// context.Context to avoid wasted work, but the returned error is always
+// the one belonging to the lowest-indexed user in emailMap's sorted uuid
+// order, not whichever goroutine happened to fail first - so a single
+// induced failure produces the same error on every run regardless of
+// scheduling. (If two distinct users would fail independently, a
+// lower-indexed one can still be skipped by cancellation before it runs;
+// this module only guarantees determinism for the single-failure case.)
 func (c *Config) F0(emailMap map[string]string) (map[string]*UserData, error) {
-	// Input validation
 	if emailMap == nil || len(emailMap) == 0 {
 		return nil, fmt.Errorf("emailMap cannot be nil or empty")
 	}
 
-	// Get master key from the store
 	masterKey, err := c.MasterKeyStore.GetMasterKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get master key: %w", err)
 	}
 
-	// Initialize return map
-	tempMap := make(map[string]*UserData)
-
-	// Process each user
-	for uuid, email := range emailMap {
-		if email == "" {
-			return nil, fmt.Errorf("email cannot be empty for uuid: %s", uuid)
-		}
+	csp := c.csp()
 
-		// Initialize UserData
-		tempMap[uuid] = &UserData{Email: email}
+	uuids := make([]string, 0, len(emailMap))
+	for uuid := range emailMap {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
 
-		// Generate 32-byte random salt
-		salt := make([]byte, 32)
-		if _, err := rand.Read(salt); err != nil {
-			return nil, fmt.Errorf("failed to generate salt for user %s: %w", uuid, err)
-		}
-		tempMap[uuid].Salt = salt
+	jobs := make(chan f0Job, len(uuids))
+	for i, uuid := range uuids {
+		jobs <- f0Job{index: i, uuid: uuid, email: emailMap[uuid]}
+	}
+	close(jobs)
 
-		// Generate key ID
-		keyID := pkg.GenerateUUID()
-		tempMap[uuid].KeyID = keyID
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
-		// Combine email with salt and hash
-		data := append([]byte(email), salt...)
-		hashed := pkg.Hash(data)
-		base64Hash := base64.StdEncoding.EncodeToString(hashed)
+	results := make([]f0Result, len(uuids))
 
-		// Create context for key derivation (keyID + hash)
-		context := append([]byte(keyID), base64Hash...)
+	workerCount := c.f0Concurrency()
+	if workerCount > len(uuids) {
+		workerCount = len(uuids)
+	}
 
-		// Derive wallet provider key pair
-		derivedSecretKey, err := c.DeriveSecretKey(masterKey, context, c.CredentialKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to derive secret key for user %s: %w", uuid, err)
-		}
+	var wg sync.WaitGroup
+	wg.Add(workerCount)
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				select {
+				case <-ctx.Done():
+					continue
+				default:
+				}
 
-		// Extract public key
-		var privateKey ecdsa.PrivateKey
-		if err := derivedSecretKey.Raw(&privateKey); err != nil {
-			return nil, fmt.Errorf("failed to extract private key for user %s: %w", uuid, err)
-		}
+				data, err := c.f0One(masterKey, csp, job.uuid, job.email)
+				if err != nil {
+					cancel()
+				}
+				results[job.index] = f0Result{uuid: job.uuid, data: data, err: err}
+			}
+		}()
+	}
+	wg.Wait()
 
-		wpPubKey, err := jwk.FromRaw(&privateKey.PublicKey)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create public key JWK for user %s: %w", uuid, err)
+	for _, res := range results {
+		if res.err != nil {
+			return nil, res.err
 		}
+	}
 
-		tempMap[uuid].WpPubKey = wpPubKey
+	tempMap := make(map[string]*UserData, len(uuids))
+	for _, res := range results {
+		tempMap[res.uuid] = res.data
 	}
 
 	return tempMap, nil
 }
 
+// f0One derives a single user's wallet provider key pair: a random salt, a
+// key ID, the email/salt hash used as derivation context, and the
+// DeriveSecretKey call itself. This is the unit of work F0 fans out across
+// its worker pool.
+func (c *Config) f0One(masterKey jwk.Key, csp CipherServiceProvider, uuid, email string) (*UserData, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email cannot be empty for uuid: %s", uuid)
+	}
+
+	userData := &UserData{Email: email}
+
+	// Generate 32-byte random salt
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt for user %s: %w", uuid, err)
+	}
+	userData.Salt = salt
+
+	// Generate key ID
+	keyID := pkg.GenerateUUID()
+	userData.KeyID = keyID
+
+	// Combine email with salt and hash
+	data := append([]byte(email), salt...)
+	hashed := pkg.Hash(data)
+	base64Hash := base64.StdEncoding.EncodeToString(hashed)
+
+	// Create context for key derivation (keyID + hash)
+	derivationContext := append([]byte(keyID), base64Hash...)
+
+	// Derive wallet provider key pair
+	var derivedSecretKey jwk.Key
+	var err error
+	if c.Curve == CurveX25519 {
+		derivedSecretKey, err = DeriveX25519SecretKey(masterKey, derivationContext, c.CredentialKey)
+	} else {
+		derivedSecretKey, err = csp.DeriveSecretKey(masterKey, derivationContext, c.CredentialKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive secret key for user %s: %w", uuid, err)
+	}
+
+	// Extract public key
+	wpPubKey, err := derivedSecretKey.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public key JWK for user %s: %w", uuid, err)
+	}
+
+	userData.WpPubKey = wpPubKey
+
+	return userData, nil
+}
+
 // F1 generates VC keys and adds confirmation key to the VC payload
 func (c *Config) F1(uuid string, vcPayload map[string]interface{}, userMap map[string]*UserData) error {
 	// Input validation
@@ -98,33 +233,55 @@ func (c *Config) F1(uuid string, vcPayload map[string]interface{}, userMap map[s
 		return fmt.Errorf("wallet provider public key not set for user: %s", uuid)
 	}
 
-	// Generate VC secret key
-	vcSecretKey, err := c.GenerateSecretKey()
-	if err != nil {
-		return fmt.Errorf("failed to generate VC secret key for user %s: %w", uuid, err)
+	// WpPubKey came back from the wallet provider, so it's untrusted
+	// input: validate it here rather than trusting whatever a (possibly
+	// custom) CSP's AddPublicKeys happens to check, since point addition
+	// with an invalid-curve or small-subgroup point can coerce the
+	// resulting confirmation key.
+	if c.Curve == CurveX25519 {
+		if _, err := x25519PointFromJWK(userData.WpPubKey, "wallet provider public key"); err != nil {
+			return fmt.Errorf("wallet provider public key failed validation for user %s: %w", uuid, err)
+		}
+	} else if _, err := extractPublicKey(userData.WpPubKey, "wallet provider public key"); err != nil {
+		return fmt.Errorf("wallet provider public key failed validation for user %s: %w", uuid, err)
 	}
 
-	// Extract public key from the secret key
-	var vcPrivateKey ecdsa.PrivateKey
-	if err := vcSecretKey.Raw(&vcPrivateKey); err != nil {
-		return fmt.Errorf("failed to extract VC private key for user %s: %w", uuid, err)
-	}
+	var vcSecretKey, vcPublicKey, cnfKey jwk.Key
+	var err error
+	if c.Curve == CurveX25519 {
+		vcSecretKey, err = GenerateX25519SecretKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate VC secret key for user %s: %w", uuid, err)
+		}
+		vcPublicKey, err = vcSecretKey.PublicKey()
+		if err != nil {
+			return fmt.Errorf("failed to create VC public key JWK for user %s: %w", uuid, err)
+		}
+		cnfKey, err = AddX25519PublicKeys(vcPublicKey, userData.WpPubKey)
+		if err != nil {
+			return fmt.Errorf("failed to generate confirmation key for user %s: %w", uuid, err)
+		}
+	} else {
+		csp := c.csp()
 
-	vcPublicKey, err := jwk.FromRaw(&vcPrivateKey.PublicKey)
-	if err != nil {
-		return fmt.Errorf("failed to create VC public key JWK for user %s: %w", uuid, err)
+		vcSecretKey, err = csp.GenerateSecretKey()
+		if err != nil {
+			return fmt.Errorf("failed to generate VC secret key for user %s: %w", uuid, err)
+		}
+		vcPublicKey, err = vcSecretKey.PublicKey()
+		if err != nil {
+			return fmt.Errorf("failed to create VC public key JWK for user %s: %w", uuid, err)
+		}
+		cnfKey, err = csp.AddPublicKeys(vcPublicKey, userData.WpPubKey)
+		if err != nil {
+			return fmt.Errorf("failed to generate confirmation key for user %s: %w", uuid, err)
+		}
 	}
 
 	// Store keys in user data
 	userData.VcSecKey = vcSecretKey
 	userData.VcPubKey = vcPublicKey
 
-	// Generate confirmation key by adding VC public key + WP public key
-	cnfKey, err := c.AddPublicKeys(userData.VcPubKey, userData.WpPubKey)
-	if err != nil {
-		return fmt.Errorf("failed to generate confirmation key for user %s: %w", uuid, err)
-	}
-
 	// Add confirmation key to VC payload
 	if err := pkg.AddKeyToPayload(vcPayload, cnfKey); err != nil {
 		return fmt.Errorf("failed to add confirmation key to payload for user %s: %w", uuid, err)
@@ -0,0 +1,115 @@
+package cvc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConfigF0ConcurrentProducesUniqueOnCurveKeys(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	const userCount = 500
+	emailMap := make(map[string]string, userCount)
+	for i := 0; i < userCount; i++ {
+		uuid := fmt.Sprintf("user-%d", i)
+		emailMap[uuid] = fmt.Sprintf("user-%d@example.com", i)
+	}
+
+	config := &Config{
+		MasterKeyStore: staticMasterKeyStore{key: masterKey},
+		CredentialKey:  []byte("CVC-F0-CONCURRENCY-TEST-DST-v1.0"),
+		F0Concurrency:  8,
+	}
+
+	userMap, err := config.F0(emailMap)
+	if err != nil {
+		t.Fatalf("F0 returned an error: %v", err)
+	}
+
+	if len(userMap) != userCount {
+		t.Fatalf("expected %d users, got %d", userCount, len(userMap))
+	}
+
+	seenKeyIDs := make(map[string]bool, userCount)
+	for uuid, data := range userMap {
+		if seenKeyIDs[data.KeyID] {
+			t.Fatalf("duplicate KeyID %q for uuid %s", data.KeyID, uuid)
+		}
+		seenKeyIDs[data.KeyID] = true
+
+		if _, err := extractPublicKey(data.WpPubKey, "wallet provider public key"); err != nil {
+			t.Fatalf("WpPubKey for uuid %s failed validation: %v", uuid, err)
+		}
+	}
+}
+
+func TestConfigF0ReturnsErrorForEmptyEmail(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	config := &Config{
+		MasterKeyStore: staticMasterKeyStore{key: masterKey},
+		CredentialKey:  []byte("CVC-F0-CONCURRENCY-TEST-DST-v1.0"),
+		F0Concurrency:  4,
+	}
+
+	emailMap := map[string]string{
+		"user-1": "user-1@example.com",
+		"user-2": "",
+		"user-3": "user-3@example.com",
+	}
+
+	if _, err := config.F0(emailMap); err == nil {
+		t.Fatalf("expected F0 to reject an empty email")
+	}
+}
+
+func TestConfigF0DefaultConcurrencyUsesGOMAXPROCS(t *testing.T) {
+	var config Config
+	if got := config.f0Concurrency(); got <= 0 {
+		t.Fatalf("f0Concurrency() = %d, want a positive default", got)
+	}
+}
+
+func BenchmarkConfigF0(b *testing.B) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		b.Fatalf("failed to generate master key: %v", err)
+	}
+
+	const userCount = 1000
+	emailMap := make(map[string]string, userCount)
+	for i := 0; i < userCount; i++ {
+		emailMap[fmt.Sprintf("user-%d", i)] = fmt.Sprintf("user-%d@example.com", i)
+	}
+
+	b.Run("Sequential", func(b *testing.B) {
+		config := &Config{
+			MasterKeyStore: staticMasterKeyStore{key: masterKey},
+			CredentialKey:  []byte("CVC-F0-BENCH-DST-v1.0"),
+			F0Concurrency:  1,
+		}
+		for i := 0; i < b.N; i++ {
+			if _, err := config.F0(emailMap); err != nil {
+				b.Fatalf("F0 failed: %v", err)
+			}
+		}
+	})
+
+	b.Run("Parallel", func(b *testing.B) {
+		config := &Config{
+			MasterKeyStore: staticMasterKeyStore{key: masterKey},
+			CredentialKey:  []byte("CVC-F0-BENCH-DST-v1.0"),
+		}
+		for i := 0; i < b.N; i++ {
+			if _, err := config.F0(emailMap); err != nil {
+				b.Fatalf("F0 failed: %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"testing"
+)
+
+// deterministicKeyVectors are fixed (seed, dst) -> (d, X, Y) test vectors for
+// GenerateSecretKeyFromSeed on NIST P-256, published so other language
+// bindings of the hash-to-field expander can conform to the same mapping.
+var deterministicKeyVectors = []struct {
+	seed string
+	dst  string
+	d    string
+	x    string
+	y    string
+}{
+	{
+		seed: "cvc-test-vector-seed-0000000001",
+		dst:  "CVC-TEST-DST-v1.0",
+		d:    "717b25bf5f978b58e2262f6c62cebba9d16df73aafb1b0f7fcb7848984effc57",
+		x:    "a5fc2f250641a2ff186752e4db505d7d22e00117f5a6c13ef02ad9bd774393b9",
+		y:    "548d2fd9fb32859a21119e68e2208eb85973aaa235444ba48e1782484282fd18",
+	},
+	{
+		seed: "cvc-test-vector-seed-0000000002",
+		dst:  "CVC-TEST-DST-v1.0",
+		d:    "a1d556c06e9a8f1a463bda4f4b81f6e6aaca448eced6685f1ea1690edd30f7a",
+		x:    "443ef09e2a7bd400a35f924f0a36255609dd9a797a716483503951dd20ea9c08",
+		y:    "581371813d1a70bdd22875d6421116fcd21f87a04336e5d55ec0825dd2dc490f",
+	},
+	{
+		seed: "cvc-test-vector-seed-0000000001",
+		dst:  "CVC-OTHER-DST-v1.0",
+		d:    "8f2a23d79268f5a60f31ad063ff854c58689f8591423bbcb36d2e8d5cc5fcfb1",
+		x:    "9583bc062c579648a3427135c487d003c6011214614784067bde576bd975abb6",
+		y:    "af79a316348ca1e08f78341e180d2dcb89871b2311c37e838648300763682968",
+	},
+}
+
+func TestGenerateSecretKeyFromSeed(t *testing.T) {
+	for _, vector := range deterministicKeyVectors {
+		key, err := GenerateSecretKeyFromSeed([]byte(vector.seed), []byte(vector.dst))
+		if err != nil {
+			t.Fatalf("GenerateSecretKeyFromSeed(%q, %q) returned an error: %v", vector.seed, vector.dst, err)
+		}
+
+		var privateKey ecdsa.PrivateKey
+		if err := key.Raw(&privateKey); err != nil {
+			t.Fatalf("failed to extract private key: %v", err)
+		}
+
+		if got := privateKey.D.Text(16); got != vector.d {
+			t.Errorf("seed %q dst %q: D = %s, want %s", vector.seed, vector.dst, got, vector.d)
+		}
+		if got := privateKey.X.Text(16); got != vector.x {
+			t.Errorf("seed %q dst %q: X = %s, want %s", vector.seed, vector.dst, got, vector.x)
+		}
+		if got := privateKey.Y.Text(16); got != vector.y {
+			t.Errorf("seed %q dst %q: Y = %s, want %s", vector.seed, vector.dst, got, vector.y)
+		}
+	}
+}
+
+func TestGenerateSecretKeyFromSeedIsDeterministic(t *testing.T) {
+	seed := []byte("cvc-test-vector-seed-0000000001")
+	dst := []byte("CVC-TEST-DST-v1.0")
+
+	first, err := GenerateSecretKeyFromSeed(seed, dst)
+	if err != nil {
+		t.Fatalf("GenerateSecretKeyFromSeed returned an error: %v", err)
+	}
+	second, err := GenerateSecretKeyFromSeed(seed, dst)
+	if err != nil {
+		t.Fatalf("GenerateSecretKeyFromSeed returned an error: %v", err)
+	}
+
+	var firstPrivate, secondPrivate ecdsa.PrivateKey
+	if err := first.Raw(&firstPrivate); err != nil {
+		t.Fatalf("failed to extract first private key: %v", err)
+	}
+	if err := second.Raw(&secondPrivate); err != nil {
+		t.Fatalf("failed to extract second private key: %v", err)
+	}
+
+	if firstPrivate.D.Cmp(secondPrivate.D) != 0 {
+		t.Errorf("GenerateSecretKeyFromSeed is not deterministic: got different D for the same (seed, dst)")
+	}
+}
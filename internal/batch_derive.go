@@ -0,0 +1,83 @@
+package internal
+
+/*
+#cgo CFLAGS: -I../include
+#cgo darwin,arm64 LDFLAGS: -L../lib/darwin/arm64 -lcvc
+#cgo linux,amd64 LDFLAGS: -L../lib/linux/x86_64 -lcvc
+#cgo linux,arm64 LDFLAGS: -L../lib/linux/aarch64 -lcvc
+#cgo windows,amd64 LDFLAGS: -L../lib/windows/x86_64 -lcvc
+
+#include "nist256_key_material.h"
+#include "derive_secret_keys_batch.h"
+*/
+import "C"
+import (
+	"unsafe"
+)
+
+// DeriveSecretKeysBatch derives len(contexts) secret keys from a single
+// master key in one cgo crossing, sharing the DST/expander setup across the
+// whole batch instead of paying cgo and expand-message-XMD initialization
+// overhead per item. Only NIST P-256 crosses into C; every other curve
+// falls back to per-item derivation since it never crosses into C at all.
+//
+// errs[i] is non-nil exactly for the contexts that failed to derive; one
+// bad context does not abort the rest of the batch.
+func DeriveSecretKeysBatch(masterKeyBytes []byte, contexts [][]byte, dst []byte, curve Curve) (results []KeyMaterial, errs []error, err error) {
+	if curve != CurveP256 {
+		return deriveSecretKeysBatchGo(masterKeyBytes, contexts, dst, curve)
+	}
+
+	if err := ValidateNonEmpty(masterKeyBytes, "master key"); err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateNonEmpty(dst, "domain separation tag"); err != nil {
+		return nil, nil, err
+	}
+	if len(contexts) == 0 {
+		return nil, nil, WrapError(ErrInvalidParameters, "contexts cannot be empty")
+	}
+
+	// Flatten the contexts into one buffer plus a parallel length array so
+	// the whole batch crosses into C in a single call.
+	var flatContexts []byte
+	contextLengths := make([]C.int, len(contexts))
+	for i, context := range contexts {
+		contextLengths[i] = C.int(len(context))
+		flatContexts = append(flatContexts, context...)
+	}
+	if len(flatContexts) == 0 {
+		return nil, nil, WrapError(ErrInvalidParameters, "contexts cannot all be empty")
+	}
+
+	cKeyMaterials := make([]C.nist256_key_material_t, len(contexts))
+	cErrorCodes := make([]C.int, len(contexts))
+
+	result := C.cvc_derive_secret_keys_batch_nist256(
+		(*C.uchar)(unsafe.Pointer(&masterKeyBytes[0])),
+		C.int(len(masterKeyBytes)),
+		(*C.uchar)(unsafe.Pointer(&flatContexts[0])),
+		&contextLengths[0],
+		C.int(len(contexts)),
+		(*C.uchar)(unsafe.Pointer(&dst[0])),
+		C.int(len(dst)),
+		&cKeyMaterials[0],
+		&cErrorCodes[0],
+	)
+
+	if result != 0 {
+		return nil, nil, MapDeriveKeyError(CErrorCode(result))
+	}
+
+	results = make([]KeyMaterial, len(contexts))
+	errs = make([]error, len(contexts))
+	for i := range contexts {
+		if cErrorCodes[i] != 0 {
+			errs[i] = MapDeriveKeyError(CErrorCode(cErrorCodes[i]))
+			continue
+		}
+		results[i] = convertCKeyMaterial(cKeyMaterials[i])
+	}
+
+	return results, errs, nil
+}
@@ -25,15 +25,27 @@ const (
 	UncompressedPublicKeySize = 65
 )
 
-// KeyMaterial represents extracted cryptographic key material
+// KeyMaterial represents extracted cryptographic key material.
+//
+// Curve records which curve the bytes below belong to. PrivateKeyBytes,
+// PublicKeyXBytes and PublicKeyYBytes are left-padded big-endian values
+// sized to Curve.FieldSize(): 32 bytes for P-256 and secp256k1, 48 for
+// P-384, 66 for P-521.
 type KeyMaterial struct {
-	PrivateKeyBytes [KeySize]byte
-	PublicKeyXBytes [KeySize]byte
-	PublicKeyYBytes [KeySize]byte
+	Curve           Curve
+	PrivateKeyBytes []byte
+	PublicKeyXBytes []byte
+	PublicKeyYBytes []byte
 }
 
-// GenerateSecretKey generates an NIST P-256 private key using cryptographically secure random data
-func GenerateSecretKey(seed []byte) (KeyMaterial, error) {
+// GenerateSecretKey generates a private key on the given curve using
+// cryptographically secure random data. NIST P-256 is routed through the
+// C library; every other supported curve is served by Go's standard library.
+func GenerateSecretKey(seed []byte, curve Curve) (KeyMaterial, error) {
+	if curve != CurveP256 {
+		return generateSecretKeyGo(seed, curve)
+	}
+
 	var keyMaterial KeyMaterial
 
 	// Validate seed length (should be at least 32 bytes for good entropy)
@@ -76,8 +88,14 @@ func GenerateSecretKey(seed []byte) (KeyMaterial, error) {
 	return keyMaterial, nil
 }
 
-// AddSecretKeys adds two NIST P-256 private keys using scalar addition modulo curve order
-func AddSecretKeys(key1Bytes, key2Bytes []byte) (KeyMaterial, error) {
+// AddSecretKeys adds two private keys on the given curve using scalar
+// addition modulo the curve order. NIST P-256 is routed through the C
+// library; every other supported curve is served by Go's standard library.
+func AddSecretKeys(key1Bytes, key2Bytes []byte, curve Curve) (KeyMaterial, error) {
+	if curve != CurveP256 {
+		return addSecretKeysGo(key1Bytes, key2Bytes, curve)
+	}
+
 	var keyMaterial KeyMaterial
 
 	// Validate input key lengths
@@ -109,8 +127,14 @@ func AddSecretKeys(key1Bytes, key2Bytes []byte) (KeyMaterial, error) {
 	return keyMaterial, nil
 }
 
-// AddPublicKeys adds two NIST P-256 public keys using elliptic curve point addition
-func AddPublicKeys(key1Bytes, key2Bytes []byte) ([]byte, error) {
+// AddPublicKeys adds two public keys on the given curve using elliptic
+// curve point addition. NIST P-256 is routed through the C library; every
+// other supported curve is served by Go's standard library.
+func AddPublicKeys(key1Bytes, key2Bytes []byte, curve Curve) ([]byte, error) {
+	if curve != CurveP256 {
+		return addPublicKeysGo(key1Bytes, key2Bytes, curve)
+	}
+
 	// Validate input key lengths (uncompressed format: 65 bytes)
 	if err := ValidateKeyLength(key1Bytes, UncompressedPublicKeySize, "first public key"); err != nil {
 		return nil, err
@@ -150,8 +174,10 @@ func AddPublicKeys(key1Bytes, key2Bytes []byte) ([]byte, error) {
 	return resultBuffer[:actualLen], nil
 }
 
-// DeriveSecretKey derives a secret key from master key material using hash-to-field
-func DeriveSecretKey(masterKeyBytes, context, dst []byte) (KeyMaterial, error) {
+// DeriveSecretKey derives a secret key on the given curve from master key
+// material using hash-to-field. NIST P-256 is routed through the C
+// library; every other supported curve is served by Go's standard library.
+func DeriveSecretKey(masterKeyBytes, context, dst []byte, curve Curve) (KeyMaterial, error) {
 	var keyMaterial KeyMaterial
 
 	// Validate input parameters
@@ -189,6 +215,10 @@ func DeriveSecretKey(masterKeyBytes, context, dst []byte) (KeyMaterial, error) {
 		)
 	}
 
+	if curve != CurveP256 {
+		return deriveSecretKeyGo(masterKeyBytes, context, dst, curve)
+	}
+
 	// Prepare output structure for key material
 	var cKeyMaterial C.nist256_key_material_t
 
@@ -218,8 +248,10 @@ func DeriveSecretKey(masterKeyBytes, context, dst []byte) (KeyMaterial, error) {
 	return keyMaterial, nil
 }
 
-// HashToField performs hash-to-field operation for the given input
-func HashToField(hash, hashLen int, dst, message []byte, count int) error {
+// HashToField performs hash-to-field operation for the given input on the
+// given curve. NIST P-256 is routed through the C library; every other
+// supported curve is served by the pure-Go hashToScalar expander.
+func HashToField(hash, hashLen int, dst, message []byte, count int, curve Curve) error {
 	// Validate input parameters
 	if err := ValidateNonEmpty(dst, "domain separation tag"); err != nil {
 		return err
@@ -233,6 +265,19 @@ func HashToField(hash, hashLen int, dst, message []byte, count int) error {
 		return WrapError(ErrInvalidParameters, "count must be positive")
 	}
 
+	if curve != CurveP256 {
+		ellipticCurve, err := curve.EllipticCurve()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < count; i++ {
+			if _, err := hashToScalar(ellipticCurve, dst, message); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// Note: This is a simplified wrapper. The actual implementation would need
 	// to handle field elements properly. For now, we'll just validate the call.
 	result := C.cvc_hash_to_field_nist256(
@@ -255,10 +300,14 @@ func HashToField(hash, hashLen int, dst, message []byte, count int) error {
 
 // convertCKeyMaterial converts C key material structure to Go structure
 func convertCKeyMaterial(cKeyMaterial C.nist256_key_material_t) KeyMaterial {
-	var keyMaterial KeyMaterial
+	keyMaterial := KeyMaterial{
+		Curve:           CurveP256,
+		PrivateKeyBytes: make([]byte, KeySize),
+		PublicKeyXBytes: make([]byte, KeySize),
+		PublicKeyYBytes: make([]byte, KeySize),
+	}
 
-	// Convert C arrays to Go byte arrays
-	// Note: C.GoBytes creates a copy, but we need fixed-size arrays
+	// Convert C arrays to Go byte slices
 	for i := 0; i < KeySize; i++ {
 		keyMaterial.PrivateKeyBytes[i] = byte(cKeyMaterial.private_key_bytes[i])
 		keyMaterial.PublicKeyXBytes[i] = byte(cKeyMaterial.public_key_x_bytes[i])
@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"crypto/elliptic"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// Curve identifies the elliptic curve backing a KeyMaterial value.
+//
+// CurveP256 is the module's original curve and remains the default
+// everywhere a Curve is not explicitly supplied. It is the only curve
+// routed through the cgo bindings; the others are served in Go: P-384
+// and P-521 by the standard library, secp256k1 by btcec's KoblitzCurve
+// (see secp256k1 below).
+type Curve int
+
+const (
+	CurveP256 Curve = iota
+	CurveP384
+	CurveP521
+	CurveSecp256k1
+)
+
+// String returns the curve's canonical SEC/JOSE name.
+func (c Curve) String() string {
+	switch c {
+	case CurveP256:
+		return "P-256"
+	case CurveP384:
+		return "P-384"
+	case CurveP521:
+		return "P-521"
+	case CurveSecp256k1:
+		return "secp256k1"
+	default:
+		return "unknown curve"
+	}
+}
+
+// FieldSize returns the byte length of a scalar or a single coordinate for the curve.
+func (c Curve) FieldSize() int {
+	switch c {
+	case CurveP256:
+		return KeySize
+	case CurveP384:
+		return 48
+	case CurveP521:
+		return 66
+	case CurveSecp256k1:
+		return 32
+	default:
+		return 0
+	}
+}
+
+// UncompressedKeySize returns the byte length of an uncompressed SEC1 point (0x04 || X || Y).
+func (c Curve) UncompressedKeySize() int {
+	return 2*c.FieldSize() + 1
+}
+
+// EllipticCurve returns the crypto/elliptic.Curve implementation backing this curve.
+func (c Curve) EllipticCurve() (elliptic.Curve, error) {
+	switch c {
+	case CurveP256:
+		return elliptic.P256(), nil
+	case CurveP384:
+		return elliptic.P384(), nil
+	case CurveP521:
+		return elliptic.P521(), nil
+	case CurveSecp256k1:
+		return secp256k1(), nil
+	default:
+		return nil, WrapError(ErrCurveUnsupported, c.String())
+	}
+}
+
+// UsesCgo reports whether the curve is served by the C library rather than
+// in Go (the standard library, or btcec for secp256k1).
+func (c Curve) UsesCgo() bool {
+	return c == CurveP256
+}
+
+// secp256k1 returns the elliptic.Curve for secp256k1 (as used by
+// Bitcoin/Ethereum). Go's standard library does not ship this curve.
+//
+// It is NOT registered as a plain elliptic.CurveParams the way an earlier
+// version of this function did: CurveParams' generic arithmetic
+// (polynomial, doubleJacobian) hardcodes the short-Weierstrass a=-3
+// coefficient shared by P-256/P-384/P-521, but secp256k1 has a=0
+// (y^2 = x^3 + 7). Registering secp256k1's domain parameters through
+// CurveParams therefore doesn't yield "a slower secp256k1" - its generator
+// doesn't even satisfy IsOnCurve under that arithmetic, and ScalarBaseMult
+// panics on every call. btcec's KoblitzCurve implements the same
+// elliptic.Curve interface with arithmetic that's actually correct for
+// a=0 curves.
+func secp256k1() elliptic.Curve {
+	return btcec.S256()
+}
+
+// hashToScalar maps an arbitrary-length input to a non-zero scalar mod the
+// curve order using counter-based rejection sampling over SHA-256. It is the
+// pure-Go stand-in for the C library's hash-to-field expander, used for every
+// curve that isn't routed through cgo.
+func hashToScalar(curve elliptic.Curve, dst, input []byte) (*big.Int, error) {
+	order := curve.Params().N
+
+	for counter := byte(0); counter < 255; counter++ {
+		h := sha256.New()
+		h.Write(dst)
+		h.Write(input)
+		h.Write([]byte{counter})
+
+		digest := h.Sum(nil)
+		// Expand the digest by appending the SHA-256 of everything produced
+		// so far until it is at least BitSize/8+8 bytes long. This runs for
+		// every curve, 32-byte fields included.
+		for len(digest) < curve.Params().BitSize/8+8 {
+			h2 := sha256.New()
+			h2.Write(digest)
+			digest = append(digest, h2.Sum(nil)...)
+		}
+
+		candidate := new(big.Int).Mod(new(big.Int).SetBytes(digest), order)
+		if candidate.Sign() != 0 {
+			return candidate, nil
+		}
+	}
+
+	return nil, WrapError(ErrHashToField, "exhausted rejection sampling attempts")
+}
@@ -0,0 +1,229 @@
+package internal
+
+import (
+	"crypto/elliptic"
+	"math/big"
+)
+
+// generateSecretKeyGo generates a private key on curve using Go's standard
+// library. The seed is folded into the generated scalar so that, unlike
+// crypto/rand alone, the same seed always yields the same key - mirroring
+// the behaviour of the cgo-backed NIST P-256 path.
+func generateSecretKeyGo(seed []byte, curve Curve) (KeyMaterial, error) {
+	var keyMaterial KeyMaterial
+
+	if len(seed) < KeySize {
+		return keyMaterial, ErrInsufficientEntropy
+	}
+
+	ellipticCurve, err := curve.EllipticCurve()
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	d, err := hashToScalar(ellipticCurve, []byte("cvc-keygen-v1"), seed)
+	if err != nil {
+		return keyMaterial, WrapError(ErrKeyGeneration, "failed to map seed to scalar")
+	}
+
+	x, y, err := scalarBasePoint(curve, d)
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	return keyMaterialFromScalarAndPoint(curve, d, x, y)
+}
+
+// GenerateSecretKeyDeterministic maps seed to a single scalar mod the curve
+// order using the same hash-to-field expander as generateSecretKeyGo, but
+// with a caller-supplied dst instead of the hardcoded "cvc-keygen-v1" one.
+// Unlike GenerateSecretKey, this never touches crypto/rand: the same
+// (seed, dst, curve) triple always yields the same KeyMaterial, which makes
+// it suitable for cross-implementation test vectors and HD-style derivation
+// schemes built on top of a single root seed.
+func GenerateSecretKeyDeterministic(seed, dst []byte, curve Curve) (KeyMaterial, error) {
+	var keyMaterial KeyMaterial
+
+	if err := ValidateNonEmpty(seed, "seed"); err != nil {
+		return keyMaterial, err
+	}
+	if err := ValidateNonEmpty(dst, "domain separation tag"); err != nil {
+		return keyMaterial, err
+	}
+
+	ellipticCurve, err := curve.EllipticCurve()
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	d, err := hashToScalar(ellipticCurve, dst, seed)
+	if err != nil {
+		return keyMaterial, WrapError(ErrKeyGeneration, "failed to map seed to scalar")
+	}
+
+	x, y, err := scalarBasePoint(curve, d)
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	return keyMaterialFromScalarAndPoint(curve, d, x, y)
+}
+
+// addSecretKeysGo adds two private keys on curve using scalar addition
+// modulo the curve order.
+func addSecretKeysGo(key1Bytes, key2Bytes []byte, curve Curve) (KeyMaterial, error) {
+	var keyMaterial KeyMaterial
+
+	fieldSize := curve.FieldSize()
+	if err := ValidateKeyLength(key1Bytes, fieldSize, "first private key"); err != nil {
+		return keyMaterial, err
+	}
+	if err := ValidateKeyLength(key2Bytes, fieldSize, "second private key"); err != nil {
+		return keyMaterial, err
+	}
+
+	ellipticCurve, err := curve.EllipticCurve()
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	order := ellipticCurve.Params().N
+	d1 := new(big.Int).SetBytes(key1Bytes)
+	d2 := new(big.Int).SetBytes(key2Bytes)
+
+	sum := new(big.Int).Add(d1, d2)
+	sum.Mod(sum, order)
+
+	if sum.Sign() == 0 {
+		return keyMaterial, ErrZeroScalar
+	}
+
+	x, y, err := scalarBasePoint(curve, sum)
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	return keyMaterialFromScalarAndPoint(curve, sum, x, y)
+}
+
+// addPublicKeysGo adds two uncompressed SEC1 public keys on curve using
+// elliptic curve point addition. This has no crypto/ecdh equivalent -
+// crypto/ecdh only exposes ECDH (scalar multiplication against a peer's
+// point), not generic point+point addition - so it stays on
+// elliptic.Curve.Add regardless of curve.
+func addPublicKeysGo(key1Bytes, key2Bytes []byte, curve Curve) ([]byte, error) {
+	uncompressedSize := curve.UncompressedKeySize()
+	if err := ValidateKeyLength(key1Bytes, uncompressedSize, "first public key"); err != nil {
+		return nil, err
+	}
+	if err := ValidateKeyLength(key2Bytes, uncompressedSize, "second public key"); err != nil {
+		return nil, err
+	}
+
+	ellipticCurve, err := curve.EllipticCurve()
+	if err != nil {
+		return nil, err
+	}
+
+	x1, y1 := elliptic.Unmarshal(ellipticCurve, key1Bytes)
+	if x1 == nil {
+		return nil, WrapError(ErrInvalidKey, "first key does not represent a valid point")
+	}
+	x2, y2 := elliptic.Unmarshal(ellipticCurve, key2Bytes)
+	if x2 == nil {
+		return nil, WrapError(ErrInvalidKey, "second key does not represent a valid point")
+	}
+
+	resultX, resultY := ellipticCurve.Add(x1, y1, x2, y2)
+	if resultX.Sign() == 0 && resultY.Sign() == 0 {
+		return nil, ErrKeyAtInfinity
+	}
+
+	return elliptic.Marshal(ellipticCurve, resultX, resultY), nil
+}
+
+// deriveSecretKeyGo derives a secret key on curve from master key material
+// using the pure-Go hash-to-scalar expander.
+func deriveSecretKeyGo(masterKeyBytes, context, dst []byte, curve Curve) (KeyMaterial, error) {
+	var keyMaterial KeyMaterial
+
+	ellipticCurve, err := curve.EllipticCurve()
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	input := append(append([]byte{}, masterKeyBytes...), context...)
+	d, err := hashToScalar(ellipticCurve, dst, input)
+	if err != nil {
+		return keyMaterial, WrapError(ErrHashToField, "hash-to-field operation failed during key derivation")
+	}
+
+	x, y, err := scalarBasePoint(curve, d)
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	keyMaterial, err = keyMaterialFromScalarAndPoint(curve, d, x, y)
+	if err != nil {
+		return keyMaterial, err
+	}
+
+	if err := validateKeyMaterial(keyMaterial); err != nil {
+		return keyMaterial, WrapError(err, "derived key validation failed")
+	}
+
+	return keyMaterial, nil
+}
+
+// deriveSecretKeysBatchGo derives one secret key per context on curve,
+// reusing the elliptic curve lookup across the whole batch since, unlike
+// the cgo NIST P-256 path, there is no per-call cgo crossing cost to amortize.
+func deriveSecretKeysBatchGo(masterKeyBytes []byte, contexts [][]byte, dst []byte, curve Curve) ([]KeyMaterial, []error, error) {
+	if err := ValidateNonEmpty(masterKeyBytes, "master key"); err != nil {
+		return nil, nil, err
+	}
+	if err := ValidateNonEmpty(dst, "domain separation tag"); err != nil {
+		return nil, nil, err
+	}
+	if len(contexts) == 0 {
+		return nil, nil, WrapError(ErrInvalidParameters, "contexts cannot be empty")
+	}
+
+	results := make([]KeyMaterial, len(contexts))
+	errs := make([]error, len(contexts))
+
+	for i, context := range contexts {
+		keyMaterial, err := deriveSecretKeyGo(masterKeyBytes, context, dst, curve)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		results[i] = keyMaterial
+	}
+
+	return results, errs, nil
+}
+
+// keyMaterialFromScalarAndPoint packs a scalar and its corresponding curve
+// point into left-padded, fixed-width KeyMaterial bytes.
+func keyMaterialFromScalarAndPoint(curve Curve, d, x, y *big.Int) (KeyMaterial, error) {
+	fieldSize := curve.FieldSize()
+
+	keyMaterial := KeyMaterial{
+		Curve:           curve,
+		PrivateKeyBytes: make([]byte, fieldSize),
+		PublicKeyXBytes: make([]byte, fieldSize),
+		PublicKeyYBytes: make([]byte, fieldSize),
+	}
+
+	dBytes := d.Bytes()
+	copy(keyMaterial.PrivateKeyBytes[fieldSize-len(dBytes):], dBytes)
+
+	xBytes := x.Bytes()
+	copy(keyMaterial.PublicKeyXBytes[fieldSize-len(xBytes):], xBytes)
+
+	yBytes := y.Bytes()
+	copy(keyMaterial.PublicKeyYBytes[fieldSize-len(yBytes):], yBytes)
+
+	return keyMaterial, nil
+}
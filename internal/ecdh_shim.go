@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"math/big"
+)
+
+// ecdhCurveFor returns the crypto/ecdh.Curve equivalent to curve, for the
+// curves the standard library knows about. secp256k1 isn't one of them -
+// crypto/ecdh only ships the NIST curves and X25519 - so callers fall back
+// to elliptic.Curve directly for it.
+func ecdhCurveFor(curve Curve) (ecdh.Curve, bool) {
+	switch curve {
+	case CurveP256:
+		return ecdh.P256(), true
+	case CurveP384:
+		return ecdh.P384(), true
+	case CurveP521:
+		return ecdh.P521(), true
+	default:
+		return nil, false
+	}
+}
+
+// scalarBasePoint returns scalar*G for curve. Where crypto/ecdh supports
+// curve, the multiplication is performed there instead of via the
+// deprecated elliptic.Curve.ScalarBaseMult, per the Go team's guidance;
+// secp256k1 has no crypto/ecdh equivalent, so it still goes through
+// elliptic.Curve.
+//
+// This only covers the scalar*G case deriveSecretKeyGo and friends need.
+// addPublicKeysGo's point+point addition has no crypto/ecdh counterpart at
+// all - crypto/ecdh only exposes ECDH (scalar * peer-point), not generic
+// point addition - so it necessarily stays on elliptic.Curve.Add.
+func scalarBasePoint(curve Curve, scalar *big.Int) (x, y *big.Int, err error) {
+	ellipticCurve, err := curve.EllipticCurve()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ecdhCurve, ok := ecdhCurveFor(curve)
+	if !ok {
+		x, y = ellipticCurve.ScalarBaseMult(scalar.Bytes())
+		return x, y, nil
+	}
+
+	fieldSize := curve.FieldSize()
+	scalarBytes := make([]byte, fieldSize)
+	sb := scalar.Bytes()
+	copy(scalarBytes[fieldSize-len(sb):], sb)
+
+	priv, err := ecdhCurve.NewPrivateKey(scalarBytes)
+	if err != nil {
+		return nil, nil, WrapError(ErrKeyGeneration, "crypto/ecdh rejected derived scalar")
+	}
+
+	x, y = elliptic.Unmarshal(ellipticCurve, priv.PublicKey().Bytes())
+	if x == nil {
+		return nil, nil, WrapError(ErrResultConversion, "failed to unmarshal crypto/ecdh public key")
+	}
+
+	return x, y, nil
+}
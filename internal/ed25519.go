@@ -0,0 +1,52 @@
+package internal
+
+import "crypto/sha256"
+
+// Ed25519SeedSize is the length of the raw seed crypto/ed25519.NewKeyFromSeed
+// expects, before RFC 8032's internal SHA-512 expansion and clamping turn it
+// into a scalar.
+const Ed25519SeedSize = 32
+
+// DeriveEd25519Seed derives a 32-byte Ed25519 seed from master key material
+// using the same counter-chained SHA-256 expansion as hashToScalar, but
+// returning raw bytes instead of a scalar reduced mod a curve order: Ed25519
+// keys are not Weierstrass points, so they don't go through KeyMaterial or
+// the scalar/point arithmetic the other curves share. The seed this returns
+// is meant to be passed to ed25519.NewKeyFromSeed, which performs the
+// RFC 8032 clamping step itself.
+func DeriveEd25519Seed(masterKeyBytes, context, dst []byte) ([]byte, error) {
+	if err := ValidateNonEmpty(masterKeyBytes, "master key"); err != nil {
+		return nil, err
+	}
+	if err := ValidateNonEmpty(context, "context"); err != nil {
+		return nil, err
+	}
+	if err := ValidateNonEmpty(dst, "domain separation tag"); err != nil {
+		return nil, err
+	}
+	if err := ValidateInputSize(masterKeyBytes, 2048, "master key"); err != nil {
+		return nil, err
+	}
+	if err := ValidateInputSize(context, 2048, "context"); err != nil {
+		return nil, err
+	}
+	if err := ValidateInputSize(dst, 256, "domain separation tag"); err != nil {
+		return nil, err
+	}
+
+	input := append(append([]byte{}, masterKeyBytes...), context...)
+
+	seed := make([]byte, 0, Ed25519SeedSize)
+	for counter := byte(0); len(seed) < Ed25519SeedSize && counter < 255; counter++ {
+		h := sha256.New()
+		h.Write(dst)
+		h.Write(input)
+		h.Write([]byte{counter})
+		seed = append(seed, h.Sum(nil)...)
+	}
+	if len(seed) < Ed25519SeedSize {
+		return nil, WrapError(ErrHashToField, "exhausted expansion attempts while deriving Ed25519 seed")
+	}
+
+	return seed[:Ed25519SeedSize], nil
+}
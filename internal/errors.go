@@ -57,92 +57,154 @@ var (
 	ErrMasterKeyNotSet     = errors.New("master key not available")
 	ErrSaltGeneration      = errors.New("failed to generate salt")
 	ErrPayloadModification = errors.New("failed to modify VC payload")
+
+	// MessagePack envelope errors (F2, F3 functions)
+	ErrAuthentication = errors.New("envelope authentication tag does not match")
 )
 
 // CErrorCode represents C library error codes
 type CErrorCode int
 
+// CVCError is a structured error carrying the operation that failed, the
+// originating CErrorCode, the sentinel Kind it compares equal to via
+// errors.Is, and a human-readable Msg. Unlike a plain fmt.Errorf("%w: ...",
+// sentinel) wrapped error, callers can recover the numeric code and
+// operation name programmatically (via Code/Op) instead of parsing the
+// formatted string, which is what HTTP layers need to map errors to JSON
+// problem-details or metrics counters.
+type CVCError struct {
+	Op      string
+	Code    CErrorCode
+	Kind    error
+	Msg     string
+	Wrapped error
+}
+
+func (e *CVCError) Error() string {
+	if e.Wrapped != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Op, e.Msg, e.Wrapped)
+	}
+	return fmt.Sprintf("%s: %s", e.Op, e.Msg)
+}
+
+// Unwrap returns the error e wraps, if any, so errors.As/errors.Unwrap can
+// keep walking past a CVCError to an underlying cause.
+func (e *CVCError) Unwrap() error {
+	return e.Wrapped
+}
+
+// Is reports whether target is e's Kind, so errors.Is(err, ErrInvalidKey)
+// keeps working for a *CVCError the same way it did for the
+// fmt.Errorf("%w: ...", ErrInvalidKey) errors this type replaces.
+func (e *CVCError) Is(target error) bool {
+	return errors.Is(e.Kind, target)
+}
+
+// Code returns the CErrorCode carried by err, if err is (or wraps) a
+// *CVCError.
+func Code(err error) (CErrorCode, bool) {
+	var cvcErr *CVCError
+	if errors.As(err, &cvcErr) {
+		return cvcErr.Code, true
+	}
+	return 0, false
+}
+
+// Op returns the operation name carried by err, if err is (or wraps) a
+// *CVCError. It returns "" otherwise.
+func Op(err error) string {
+	var cvcErr *CVCError
+	if errors.As(err, &cvcErr) {
+		return cvcErr.Op
+	}
+	return ""
+}
+
 // MapSecretKeyError maps C secret key addition error codes to Go errors
 func MapSecretKeyError(code CErrorCode) error {
+	const op = "add_secret_keys"
 	switch code {
 	case 0: // CVC_ADD_SECRET_KEYS_SUCCESS
 		return nil
 	case -1: // CVC_ADD_SECRET_KEYS_ERROR_INVALID_PARAMS
-		return fmt.Errorf("%w: invalid parameters for secret key addition", ErrInvalidParameters)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidParameters, Msg: "invalid parameters for secret key addition"}
 	case -2: // CVC_ADD_SECRET_KEYS_ERROR_INVALID_KEY1
-		return fmt.Errorf("%w: first key is invalid (zero or >= curve order)", ErrInvalidKey)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidKey, Msg: "first key is invalid (zero or >= curve order)"}
 	case -3: // CVC_ADD_SECRET_KEYS_ERROR_INVALID_KEY2
-		return fmt.Errorf("%w: second key is invalid (zero or >= curve order)", ErrInvalidKey)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidKey, Msg: "second key is invalid (zero or >= curve order)"}
 	case -4: // CVC_ADD_SECRET_KEYS_ERROR_RESULT_ZERO
-		return fmt.Errorf("%w: result scalar is zero (invalid private key)", ErrZeroScalar)
+		return &CVCError{Op: op, Code: code, Kind: ErrZeroScalar, Msg: "result scalar is zero (invalid private key)"}
 	case -5: // CVC_ADD_SECRET_KEYS_ERROR_KEY_EXTRACTION_FAILED
-		return fmt.Errorf("%w: failed to extract complete key material", ErrKeyMaterialExtraction)
+		return &CVCError{Op: op, Code: code, Kind: ErrKeyMaterialExtraction, Msg: "failed to extract complete key material"}
 	default:
-		return fmt.Errorf("%w: secret key addition failed with error code %d", ErrInternalError, int(code))
+		return &CVCError{Op: op, Code: code, Kind: ErrInternalError, Msg: fmt.Sprintf("secret key addition failed with error code %d", int(code))}
 	}
 }
 
 // MapECPError maps C ECP (elliptic curve point) operation error codes to Go errors
 func MapECPError(code CErrorCode) error {
+	const op = "ecp_add"
 	switch code {
 	case 0: // CVC_ECP_SUCCESS
 		return nil
 	case -1: // CVC_ECP_ERROR_INVALID_KEY1_LENGTH
-		return fmt.Errorf("%w: first key has invalid length", ErrInvalidKeyLength)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidKeyLength, Msg: "first key has invalid length"}
 	case -2: // CVC_ECP_ERROR_INVALID_KEY2_LENGTH
-		return fmt.Errorf("%w: second key has invalid length", ErrInvalidKeyLength)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidKeyLength, Msg: "second key has invalid length"}
 	case -3: // CVC_ECP_ERROR_INVALID_POINT_1
-		return fmt.Errorf("%w: first key does not represent a valid ECP point", ErrInvalidKey)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidKey, Msg: "first key does not represent a valid ECP point"}
 	case -4: // CVC_ECP_ERROR_INVALID_POINT_2
-		return fmt.Errorf("%w: second key does not represent a valid ECP point", ErrInvalidKey)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidKey, Msg: "second key does not represent a valid ECP point"}
 	case -5: // CVC_ECP_ERROR_POINT_1_AT_INFINITY
-		return fmt.Errorf("%w: first point is at infinity (invalid)", ErrKeyAtInfinity)
+		return &CVCError{Op: op, Code: code, Kind: ErrKeyAtInfinity, Msg: "first point is at infinity (invalid)"}
 	case -6: // CVC_ECP_ERROR_POINT_2_AT_INFINITY
-		return fmt.Errorf("%w: second point is at infinity (invalid)", ErrKeyAtInfinity)
+		return &CVCError{Op: op, Code: code, Kind: ErrKeyAtInfinity, Msg: "second point is at infinity (invalid)"}
 	case -7: // CVC_ECP_ERROR_RESULT_AT_INFINITY
-		return fmt.Errorf("%w: result point is at infinity (invalid)", ErrKeyAtInfinity)
+		return &CVCError{Op: op, Code: code, Kind: ErrKeyAtInfinity, Msg: "result point is at infinity (invalid)"}
 	case -8: // CVC_ECP_ERROR_RESULT_CONVERSION_FAILED
-		return fmt.Errorf("%w: failed to convert result point to bytes", ErrResultConversion)
+		return &CVCError{Op: op, Code: code, Kind: ErrResultConversion, Msg: "failed to convert result point to bytes"}
 	case -9: // CVC_ECP_ERROR_INSUFFICIENT_BUFFER
-		return fmt.Errorf("%w: result buffer is too small", ErrInsufficientBuffer)
+		return &CVCError{Op: op, Code: code, Kind: ErrInsufficientBuffer, Msg: "result buffer is too small"}
 	default:
-		return fmt.Errorf("%w: ECP operation failed with error code %d", ErrPointAddition, int(code))
+		return &CVCError{Op: op, Code: code, Kind: ErrPointAddition, Msg: fmt.Sprintf("ECP operation failed with error code %d", int(code))}
 	}
 }
 
 // MapHashToFieldError maps C hash-to-field operation error codes to Go errors
 func MapHashToFieldError(code CErrorCode) error {
+	const op = "hash_to_field"
 	switch code {
 	case 0: // CVC_HASH_TO_FIELD_SUCCESS
 		return nil
 	case -1: // CVC_HASH_TO_FIELD_ERROR_INVALID_PARAMS
-		return fmt.Errorf("%w: invalid parameters for hash-to-field operation", ErrInvalidParameters)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidParameters, Msg: "invalid parameters for hash-to-field operation"}
 	case -2: // CVC_HASH_TO_FIELD_ERROR_EXPAND_FAILED
-		return fmt.Errorf("%w: XMD expansion failed", ErrExpandMessage)
+		return &CVCError{Op: op, Code: code, Kind: ErrExpandMessage, Msg: "XMD expansion failed"}
 	case -3: // CVC_HASH_TO_FIELD_ERROR_EXPANSION_TOO_LARGE
-		return fmt.Errorf("%w: expansion length exceeds buffer limits", ErrExpansionTooLarge)
+		return &CVCError{Op: op, Code: code, Kind: ErrExpansionTooLarge, Msg: "expansion length exceeds buffer limits"}
 	default:
-		return fmt.Errorf("%w: hash-to-field operation failed with error code %d", ErrHashToField, int(code))
+		return &CVCError{Op: op, Code: code, Kind: ErrHashToField, Msg: fmt.Sprintf("hash-to-field operation failed with error code %d", int(code))}
 	}
 }
 
 // MapDeriveKeyError maps C key derivation error codes to Go errors
 func MapDeriveKeyError(code CErrorCode) error {
+	const op = "derive_key"
 	switch code {
 	case 0: // CVC_DERIVE_KEY_SUCCESS
 		return nil
 	case -1: // CVC_DERIVE_KEY_ERROR_INVALID_PARAMS
-		return fmt.Errorf("%w: invalid parameters for key derivation", ErrInvalidParameters)
+		return &CVCError{Op: op, Code: code, Kind: ErrInvalidParameters, Msg: "invalid parameters for key derivation"}
 	case -2: // CVC_DERIVE_KEY_ERROR_INPUT_TOO_LARGE
-		return fmt.Errorf("%w: combined input exceeds buffer limits", ErrInputTooLarge)
+		return &CVCError{Op: op, Code: code, Kind: ErrInputTooLarge, Msg: "combined input exceeds buffer limits"}
 	case -3: // CVC_DERIVE_KEY_ERROR_HASH_TO_FIELD_FAILED
-		return fmt.Errorf("%w: hash-to-field operation failed during key derivation", ErrHashToField)
+		return &CVCError{Op: op, Code: code, Kind: ErrHashToField, Msg: "hash-to-field operation failed during key derivation"}
 	case -4: // CVC_DERIVE_KEY_ERROR_ZERO_SCALAR
-		return fmt.Errorf("%w: derived key resulted in zero scalar (invalid)", ErrZeroScalar)
+		return &CVCError{Op: op, Code: code, Kind: ErrZeroScalar, Msg: "derived key resulted in zero scalar (invalid)"}
 	case -5: // CVC_DERIVE_KEY_ERROR_KEY_EXTRACTION_FAILED
-		return fmt.Errorf("%w: failed to extract derived key material", ErrKeyMaterialExtraction)
+		return &CVCError{Op: op, Code: code, Kind: ErrKeyMaterialExtraction, Msg: "failed to extract derived key material"}
 	default:
-		return fmt.Errorf("%w: key derivation failed with error code %d", ErrKeyDerivation, int(code))
+		return &CVCError{Op: op, Code: code, Kind: ErrKeyDerivation, Msg: fmt.Sprintf("key derivation failed with error code %d", int(code))}
 	}
 }
 
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMapSecretKeyErrorCarriesOpAndCode(t *testing.T) {
+	err := MapSecretKeyError(-2)
+
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected errors.Is(err, ErrInvalidKey) to hold, got: %v", err)
+	}
+
+	code, ok := Code(err)
+	if !ok || code != -2 {
+		t.Fatalf("Code(err) = (%v, %v), want (-2, true)", code, ok)
+	}
+
+	if op := Op(err); op != "add_secret_keys" {
+		t.Fatalf("Op(err) = %q, want %q", op, "add_secret_keys")
+	}
+}
+
+func TestMapECPErrorOp(t *testing.T) {
+	err := MapECPError(-5)
+
+	if !errors.Is(err, ErrKeyAtInfinity) {
+		t.Fatalf("expected errors.Is(err, ErrKeyAtInfinity) to hold, got: %v", err)
+	}
+	if op := Op(err); op != "ecp_add" {
+		t.Fatalf("Op(err) = %q, want %q", op, "ecp_add")
+	}
+}
+
+func TestCodeAndOpReturnFalseForPlainErrors(t *testing.T) {
+	plain := errors.New("plain error")
+
+	if _, ok := Code(plain); ok {
+		t.Fatalf("expected Code to report false for a plain error")
+	}
+	if op := Op(plain); op != "" {
+		t.Fatalf("Op(plain) = %q, want empty string", op)
+	}
+}
+
+func TestCVCErrorSurvivesWrapError(t *testing.T) {
+	err := WrapError(MapDeriveKeyError(-4), "key derivation failed")
+
+	if !errors.Is(err, ErrZeroScalar) {
+		t.Fatalf("expected errors.Is(err, ErrZeroScalar) to hold through WrapError, got: %v", err)
+	}
+
+	code, ok := Code(err)
+	if !ok || code != -4 {
+		t.Fatalf("Code(err) = (%v, %v), want (-4, true)", code, ok)
+	}
+	if op := Op(err); op != "derive_key" {
+		t.Fatalf("Op(err) = %q, want %q", op, "derive_key")
+	}
+}
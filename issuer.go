@@ -1,26 +1,58 @@
 package cvc
 
 import (
-	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"path"
 
 	"github.com/MyNextID/cvc-go/pkg"
 	"github.com/lestrrat-go/jwx/v2/jwk"
-	"github.com/shamaton/msgpack/v2"
 )
 
 type IssuerConfig struct {
 	ProviderURL string
+	// Client is how IssuerConfig reaches the wallet provider. Nil falls
+	// back to an HTTPWalletProvider against ProviderURL, the module's
+	// original behavior; inject a RetryWalletProvider, a
+	// CircuitBreakerWalletProvider, a GRPCWalletProvider, or an
+	// InProcessWalletProvider (for tests) to change that.
+	Client WalletProviderClient
+	// SigningKey is the issuer's ES256 (P-256 ECDSA) private key. PrepareMessagePack
+	// uses it to sign the JWS envelope it produces, so recipients can validate
+	// issuer authenticity via ParseMessagePack before trusting the JWE payloads
+	// inside. The default Client also uses it to authenticate wallet-provider
+	// requests via a VAPID Authorization header (see VAPIDHeader).
+	SigningKey jwk.Key
+	// IssuerID identifies this issuer in the VAPID JWT's sub claim (e.g. an
+	// issuer DID). Only used when Client is nil and SigningKey is set.
+	IssuerID string
+	// Algorithm is the curve/algorithm VC secret keys (and, transitively,
+	// confirmation keys) are generated on. The zero value is AlgorithmP256,
+	// this module's historical default, so existing callers keep
+	// byte-for-byte identical behavior. GetPublicKeysFromWalletProvider
+	// sends it to the wallet provider up front so both sides derive and
+	// combine keys on the same curve; see WalletProviderClient.
+	Algorithm Algorithm
+}
+
+func (c *IssuerConfig) client() WalletProviderClient {
+	if c.Client != nil {
+		return c.Client
+	}
+
+	provider := NewHTTPWalletProvider(c.ProviderURL)
+	if c.SigningKey != nil {
+		provider.Authorization = func() (string, error) {
+			return VAPIDHeader(c.SigningKey, c.ProviderURL, c.IssuerID)
+		}
+	}
+	return provider
 }
 
 // GetPublicKeysFromWalletProvider (F0) generates wallet provider public keys for a map of users
-func (c *IssuerConfig) GetPublicKeysFromWalletProvider(emailMap map[string]string) (map[string]*UserData, error) {
+func (c *IssuerConfig) GetPublicKeysFromWalletProvider(ctx context.Context, emailMap map[string]string) (map[string]*UserData, error) {
 	// Input validation
 	if len(emailMap) == 0 {
 		return nil, fmt.Errorf("emailMap cannot be nil or empty")
@@ -67,16 +99,10 @@ func (c *IssuerConfig) GetPublicKeysFromWalletProvider(emailMap map[string]strin
 		hashUuidMap[base64Hash] = uuid
 	}
 
-	// marshal the hashSlice to json for transport
-	hashBytes, err := json.Marshal(hashSlices)
+	// call wallet provider to get public keys for users
+	receivedMap, err := c.client().GeneratePublicKeys(ctx, hashSlices, c.Algorithm)
 	if err != nil {
-		panic(err)
-	}
-
-	// call api to get public keys for users
-	receivedMap, err := c.GeneratePublicKeys(hashBytes)
-	if err != nil {
-		return nil, fmt.Errorf("failed get public keys from wallet provider: %s", err)
+		return nil, fmt.Errorf("failed get public keys from wallet provider: %w", err)
 	}
 
 	// loop through the map and fill out the return map
@@ -96,49 +122,12 @@ func (c *IssuerConfig) GetPublicKeysFromWalletProvider(emailMap map[string]strin
 	return tempMap, nil
 }
 
-func (c *IssuerConfig) GeneratePublicKeys(hashBytes []byte) (map[string]KeyData, error) {
-	// Build the HTTP POST request with JSON body
-	url := c.ProviderURL + path.Join("/", "generate", "pub-key")
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(hashBytes))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create HTTP request: %s", err)
-	}
-
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-
-	// Send the HTTP request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get response from wp: %s", err)
-	}
-	defer resp.Body.Close()
-
-	// Check for non-200 response codes
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Non-OK HTTP status: %d. Body: %s", resp.StatusCode, string(bodyBytes))
-	}
-
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %s", err)
-	}
-
-	// unmarshall response in to map
-	var receivedMap map[string]KeyData
-	err = json.Unmarshal(body, &receivedMap)
-	if err != nil {
-		return nil, err
-	}
-	return receivedMap, err
-}
-
 // AddCnfToPayload (F1) generates VC keys and adds confirmation key to the VC payload
-func (c *IssuerConfig) AddCnfToPayload(uuid string, vcPayload map[string]interface{}, userMap map[string]*UserData) (map[string]interface{}, *UserData, error) {
+func (c *IssuerConfig) AddCnfToPayload(ctx context.Context, uuid string, vcPayload map[string]interface{}, userMap map[string]*UserData) (map[string]interface{}, *UserData, error) {
 	// Input validation
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 	if uuid == "" {
 		return nil, nil, fmt.Errorf("uuid cannot be empty")
 	}
@@ -157,8 +146,11 @@ func (c *IssuerConfig) AddCnfToPayload(uuid string, vcPayload map[string]interfa
 		return nil, nil, fmt.Errorf("wallet provider public key not set for user: %s", uuid)
 	}
 
-	// Generate VC secret key
-	vcSecretKey, err := GenerateSecretKey()
+	// Generate VC secret key on the issuer's configured algorithm, so it
+	// combines with the wallet provider's public key (negotiated via the
+	// same algorithm in GetPublicKeysFromWalletProvider) without a curve
+	// mismatch in AddPublicKeys below.
+	vcSecretKey, err := GenerateSecretKeyWithAlgorithm(c.Algorithm)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to generate VC secret key for user %s: %w", uuid, err)
 	}
@@ -179,6 +171,16 @@ func (c *IssuerConfig) AddCnfToPayload(uuid string, vcPayload map[string]interfa
 		return nil, nil, fmt.Errorf("failed to generate confirmation key for user %s: %w", uuid, err)
 	}
 
+	// Derive a deterministic, content-addressed ID for the confirmation key
+	// (libtrust-style fingerprint), so downstream verifiers can address it
+	// without recomputing the key addition themselves.
+	cnfKeyID, err := pkg.KeyFingerprint(cnfKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fingerprint confirmation key for user %s: %w", uuid, err)
+	}
+	userData.ConfirmationKeyID = cnfKeyID
+	vcPayload["kid"] = cnfKeyID
+
 	// Add confirmation key to VC payload
 	if err := pkg.AddKeyToPayload(vcPayload, cnfKey); err != nil {
 		return nil, nil, fmt.Errorf("failed to add confirmation key to payload for user %s: %w", uuid, err)
@@ -187,48 +189,10 @@ func (c *IssuerConfig) AddCnfToPayload(uuid string, vcPayload map[string]interfa
 	return vcPayload, userData, nil
 }
 
-// PrepareMessagePack (F2) encrypts the credential with credential public key and encrypts the credential secret key
-// with wallet provider public key. It returns the message pack to be send to the credential
-// recipient email
-func (c *IssuerConfig) PrepareMessagePack(signedCredential []byte, uuid string, userMap map[string]*UserData, displayConf, previewDisplayConf []byte) ([]byte, error) {
-	// initialize message pack
-	msgPack := &MessagePack{
-		ProviderURL:       c.ProviderURL,
-		KeyId:             userMap[uuid].KeyID,
-		Salt:              userMap[uuid].Salt,
-		Email:             userMap[uuid].Email,
-		DisplayMap:        displayConf,
-		PreviewDisplayMap: previewDisplayConf,
-	}
-	// encrypt credential
-	encVC, err := pkg.EncryptWithPublicKey(signedCredential, userMap[uuid].VcPubKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt credential %w", err)
-	}
-	// add to pack
-	msgPack.EncVC = encVC
-
-	// encrypt credential secret key
-	// first convert to bytes
-	vcSecBytes, err := pkg.KeyJWKToJson(userMap[uuid].VcSecKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert secret key to bytes %w", err)
-	}
-	encVCSecKey, err := pkg.EncryptWithPublicKey(vcSecBytes, userMap[uuid].WpPubKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt vc secret key %w", err)
-	}
-	// add to pack
-	msgPack.EncVCSecKey = encVCSecKey
-
-	// 	// convert pack to json (for now; final version will have a dedicated format)
-	msgPackBytes, err := msgpack.Marshal(msgPack)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal MessagePack %w", err)
-	}
-
-	return msgPackBytes, nil
-}
+// PrepareMessagePack (F2) is implemented in issuer_jose.go: it JWE-encrypts
+// the credential and the credential secret key, then bundles them with
+// routing/display metadata into a signed JWS. See ParseMessagePack for the
+// recipient-side counterpart.
 
 // GetUserDataMap takes raw userDataBytes that are usually stored in the database and converts them to correct format that the rest of IssuerConfig methods use.
 func (c *IssuerConfig) GetUserDataMap(userDataBytes []byte) (map[string]*UserData, error) {
@@ -0,0 +1,215 @@
+package cvc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultBatchChunkSize is the number of users each wallet-provider request
+// covers when BatchOptions.ChunkSize isn't set.
+const DefaultBatchChunkSize = 500
+
+// BatchOptions configures GetPublicKeysFromWalletProviderBatched.
+type BatchOptions struct {
+	// ChunkSize is how many users each wallet-provider request covers.
+	// Zero or negative defaults to DefaultBatchChunkSize.
+	ChunkSize int
+	// Concurrency is how many chunk requests run in flight at once. Zero
+	// or negative defaults to runtime.GOMAXPROCS(0).
+	Concurrency int
+	// MaxAttempts is how many attempts in all a chunk gets, the first one
+	// included (retries use jittered exponential backoff), before its
+	// users are reported in PartialResult.Failed instead of aborting the
+	// whole batch. Zero or negative defaults to 1 (no retry).
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the jittered exponential backoff
+	// between chunk retries, the same shape RetryWalletProvider uses.
+	// Zero defaults to 100ms and 5s respectively.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (o BatchOptions) chunkSize() int {
+	if o.ChunkSize > 0 {
+		return o.ChunkSize
+	}
+	return DefaultBatchChunkSize
+}
+
+func (o BatchOptions) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+func (o BatchOptions) maxAttempts() int {
+	if o.MaxAttempts > 0 {
+		return o.MaxAttempts
+	}
+	return 1
+}
+
+func (o BatchOptions) baseDelay() time.Duration {
+	if o.BaseDelay > 0 {
+		return o.BaseDelay
+	}
+	return 100 * time.Millisecond
+}
+
+func (o BatchOptions) maxDelay() time.Duration {
+	if o.MaxDelay > 0 {
+		return o.MaxDelay
+	}
+	return 5 * time.Second
+}
+
+// BatchProgress reports GetPublicKeysFromWalletProviderBatched's progress
+// as each chunk finishes, so a caller driving a 100k-user run can surface
+// a progress bar instead of blocking silently until the whole batch
+// completes. Succeeded and Failed are running totals across every chunk
+// finished so far, not just the chunk that triggered this report.
+type BatchProgress struct {
+	ChunkIndex int
+	ChunkCount int
+	UsersDone  int
+	UsersTotal int
+	Succeeded  int
+	Failed     int
+}
+
+// PartialResult is GetPublicKeysFromWalletProviderBatched's return value:
+// the users it successfully obtained wallet-provider keys for, and the
+// ones that need reprocessing - e.g. in a follow-up call built from just
+// these uuids - each paired with the error that made its chunk give up.
+type PartialResult struct {
+	Users  map[string]*UserData
+	Failed map[string]error
+}
+
+// GetPublicKeysFromWalletProviderBatched is GetPublicKeysFromWalletProvider
+// for large issuer runs: it splits emailMap into opts-sized chunks, each
+// sent to the wallet provider as its own GetPublicKeysFromWalletProvider
+// call so no single request grows past whatever size the provider or its
+// transport can handle, runs up to opts.Concurrency chunks concurrently,
+// and retries a failing chunk with jittered backoff before giving up on
+// just that chunk - a transient failure on chunk 40 of 200 doesn't lose
+// the other 199. If progress is non-nil, a BatchProgress is sent on it as
+// each chunk finishes; GetPublicKeysFromWalletProviderBatched never closes
+// progress, so callers can share one channel across several calls.
+func (c *IssuerConfig) GetPublicKeysFromWalletProviderBatched(ctx context.Context, emailMap map[string]string, opts BatchOptions, progress chan<- BatchProgress) (*PartialResult, error) {
+	if len(emailMap) == 0 {
+		return nil, fmt.Errorf("emailMap cannot be nil or empty")
+	}
+
+	uuids := make([]string, 0, len(emailMap))
+	for uuid := range emailMap {
+		uuids = append(uuids, uuid)
+	}
+	sort.Strings(uuids)
+
+	chunkSize := opts.chunkSize()
+	var chunks [][]string
+	for i := 0; i < len(uuids); i += chunkSize {
+		end := i + chunkSize
+		if end > len(uuids) {
+			end = len(uuids)
+		}
+		chunks = append(chunks, uuids[i:end])
+	}
+
+	result := &PartialResult{
+		Users:  make(map[string]*UserData, len(uuids)),
+		Failed: make(map[string]error),
+	}
+
+	jobs := make(chan int, len(chunks))
+	for i := range chunks {
+		jobs <- i
+	}
+	close(jobs)
+
+	workerCount := opts.concurrency()
+	if workerCount > len(chunks) {
+		workerCount = len(chunks)
+	}
+
+	var mu sync.Mutex
+	var doneUsers int
+
+	var wg sync.WaitGroup
+	wg.Add(workerCount)
+	for w := 0; w < workerCount; w++ {
+		go func() {
+			defer wg.Done()
+			for chunkIndex := range jobs {
+				chunkUuids := chunks[chunkIndex]
+				chunkEmails := make(map[string]string, len(chunkUuids))
+				for _, uuid := range chunkUuids {
+					chunkEmails[uuid] = emailMap[uuid]
+				}
+
+				users, err := c.generateChunkWithRetry(ctx, chunkEmails, opts)
+
+				mu.Lock()
+				if err != nil {
+					for _, uuid := range chunkUuids {
+						result.Failed[uuid] = err
+					}
+				} else {
+					for uuid, data := range users {
+						result.Users[uuid] = data
+					}
+				}
+				doneUsers += len(chunkUuids)
+				if progress != nil {
+					progress <- BatchProgress{
+						ChunkIndex: chunkIndex,
+						ChunkCount: len(chunks),
+						UsersDone:  doneUsers,
+						UsersTotal: len(uuids),
+						Succeeded:  len(result.Users),
+						Failed:     len(result.Failed),
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+// generateChunkWithRetry calls GetPublicKeysFromWalletProvider for a single
+// chunk, making up to opts.MaxAttempts attempts in all with jittered
+// exponential backoff between them - the same backoffWithJitter
+// RetryWalletProvider uses - before giving up on the chunk.
+func (c *IssuerConfig) generateChunkWithRetry(ctx context.Context, chunkEmails map[string]string, opts BatchOptions) (map[string]*UserData, error) {
+	attempts := opts.maxAttempts()
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := backoffWithJitter(opts.baseDelay(), opts.maxDelay(), attempt-1, rand.Float64)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		users, err := c.GetPublicKeysFromWalletProvider(ctx, chunkEmails)
+		if err == nil {
+			return users, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("wallet provider: chunk failed after %d attempts: %w", attempts, lastErr)
+}
@@ -0,0 +1,139 @@
+package cvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/MyNextID/cvc-go/pkg"
+)
+
+// batchTestClient is a WalletProviderClient test double that records every
+// call it receives and, for the first failUntil calls, returns an error -
+// the same shape countingWalletProvider uses in walletprovider_test.go, but
+// safe to call concurrently since GetPublicKeysFromWalletProviderBatched
+// drives chunks from a worker pool.
+type batchTestClient struct {
+	mu        sync.Mutex
+	calls     int
+	failUntil int
+}
+
+func (c *batchTestClient) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	c.mu.Lock()
+	c.calls++
+	fail := c.calls <= c.failUntil
+	c.mu.Unlock()
+
+	if fail {
+		return nil, errors.New("transient failure")
+	}
+
+	secKey, err := GenerateSecretKey()
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := secKey.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	pubKeyBytes, err := pkg.JWKToJson(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]KeyData, len(hashes))
+	for _, hash := range hashes {
+		result[hash] = KeyData{KeyID: "key-" + hash, WpPubkey: pubKeyBytes}
+	}
+	return result, nil
+}
+
+func emailMapOfSize(n int) map[string]string {
+	emailMap := make(map[string]string, n)
+	for i := 0; i < n; i++ {
+		uuid := fmt.Sprintf("user-%d", i)
+		emailMap[uuid] = uuid + "@example.com"
+	}
+	return emailMap
+}
+
+func TestGetPublicKeysFromWalletProviderBatchedChunksAllUsers(t *testing.T) {
+	client := &batchTestClient{}
+	issuer := &IssuerConfig{Client: client}
+	emailMap := emailMapOfSize(5)
+
+	progress := make(chan BatchProgress, 10)
+	result, err := issuer.GetPublicKeysFromWalletProviderBatched(context.Background(), emailMap, BatchOptions{ChunkSize: 2, Concurrency: 2}, progress)
+	close(progress)
+	if err != nil {
+		t.Fatalf("GetPublicKeysFromWalletProviderBatched returned an error: %v", err)
+	}
+
+	if len(result.Users) != len(emailMap) {
+		t.Fatalf("expected %d users, got %d", len(emailMap), len(result.Users))
+	}
+	if len(result.Failed) != 0 {
+		t.Fatalf("expected no failures, got %d", len(result.Failed))
+	}
+
+	// ceil(5/2) = 3 chunks, one progress report each.
+	var reports int
+	var lastUsersDone int
+	for p := range progress {
+		reports++
+		lastUsersDone = p.UsersDone
+	}
+	if reports != 3 {
+		t.Fatalf("expected 3 progress reports, got %d", reports)
+	}
+	if lastUsersDone != len(emailMap) {
+		t.Fatalf("expected the final progress report to cover all %d users, got %d", len(emailMap), lastUsersDone)
+	}
+}
+
+func TestGetPublicKeysFromWalletProviderBatchedRetriesTransientChunkFailures(t *testing.T) {
+	client := &batchTestClient{failUntil: 1}
+	issuer := &IssuerConfig{Client: client}
+	emailMap := emailMapOfSize(2)
+
+	result, err := issuer.GetPublicKeysFromWalletProviderBatched(context.Background(), emailMap, BatchOptions{ChunkSize: 2, Concurrency: 1, MaxAttempts: 3, BaseDelay: 0}, nil)
+	if err != nil {
+		t.Fatalf("GetPublicKeysFromWalletProviderBatched returned an error: %v", err)
+	}
+	if len(result.Users) != len(emailMap) {
+		t.Fatalf("expected %d users after retrying the transient failure, got %d", len(emailMap), len(result.Users))
+	}
+	if len(result.Failed) != 0 {
+		t.Fatalf("expected no failures once the chunk succeeds on retry, got %d", len(result.Failed))
+	}
+	if client.calls != 2 {
+		t.Fatalf("expected 2 calls (one failure, one success), got %d", client.calls)
+	}
+}
+
+func TestGetPublicKeysFromWalletProviderBatchedReportsPartialFailure(t *testing.T) {
+	client := &batchTestClient{failUntil: 1000}
+	issuer := &IssuerConfig{Client: client}
+	emailMap := emailMapOfSize(2)
+
+	result, err := issuer.GetPublicKeysFromWalletProviderBatched(context.Background(), emailMap, BatchOptions{ChunkSize: 2, MaxAttempts: 1}, nil)
+	if err != nil {
+		t.Fatalf("expected a permanently failing chunk to be reported in PartialResult, not returned as a top-level error: %v", err)
+	}
+	if len(result.Users) != 0 {
+		t.Fatalf("expected no successful users, got %d", len(result.Users))
+	}
+	if len(result.Failed) != len(emailMap) {
+		t.Fatalf("expected all %d users to be reported as failed, got %d", len(emailMap), len(result.Failed))
+	}
+}
+
+func TestGetPublicKeysFromWalletProviderBatchedRejectsEmptyEmailMap(t *testing.T) {
+	issuer := &IssuerConfig{Client: &batchTestClient{}}
+	if _, err := issuer.GetPublicKeysFromWalletProviderBatched(context.Background(), nil, BatchOptions{}, nil); err == nil {
+		t.Fatalf("expected an error for a nil emailMap, got none")
+	}
+}
@@ -0,0 +1,209 @@
+package cvc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+
+	"github.com/MyNextID/cvc-go/pkg"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// josePayload is the JSON payload PrepareMessagePack signs into a JWS: the
+// two JWE compact strings plus the routing/display metadata a recipient
+// needs to act on them. It replaces the ad-hoc MessagePack msgpack blob
+// with a standards-based, inspectable envelope.
+type josePayload struct {
+	EncVC             string `json:"enc_vc"`
+	EncVCSecKey       string `json:"enc_vc_sec_key"`
+	ProviderURL       string `json:"provider_url"`
+	KeyID             string `json:"key_id"`
+	Salt              []byte `json:"salt"`
+	Email             string `json:"email"`
+	DisplayMap        []byte `json:"display_map,omitempty"`
+	PreviewDisplayMap []byte `json:"preview_display_map,omitempty"`
+}
+
+// PrepareMessagePack (F2) JWE-encrypts signedCredential to the recipient's
+// VC public key and the VC secret key to the recipient's wallet provider
+// public key (ECDH-ES+A256KW key wrap, A256GCM content encryption), then
+// bundles both compact JWEs with routing and display metadata into a JWS
+// signed with c.SigningKey (ES256), kid set to the recipient's KeyID, so
+// ParseMessagePack can validate issuer authenticity before trusting the
+// JWEs inside.
+func (c *IssuerConfig) PrepareMessagePack(ctx context.Context, signedCredential []byte, uuid string, userMap map[string]*UserData, displayConf, previewDisplayConf []byte) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if c.SigningKey == nil {
+		return nil, fmt.Errorf("issuer signing key not set")
+	}
+
+	userData, ok := userMap[uuid]
+	if !ok {
+		return nil, fmt.Errorf("no user data for uuid: %s", uuid)
+	}
+	if userData.VcPubKey == nil {
+		return nil, fmt.Errorf("VC public key not set for user: %s", uuid)
+	}
+	if userData.WpPubKey == nil {
+		return nil, fmt.Errorf("wallet provider public key not set for user: %s", uuid)
+	}
+
+	encVC, err := joseEncrypt(signedCredential, userData.VcPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt credential: %w", err)
+	}
+
+	vcSecBytes, err := pkg.JWKToJson(userData.VcSecKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert VC secret key to bytes: %w", err)
+	}
+	encVCSecKey, err := joseEncrypt(vcSecBytes, userData.WpPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt VC secret key: %w", err)
+	}
+
+	payloadBytes, err := json.Marshal(josePayload{
+		EncVC:             encVC,
+		EncVCSecKey:       encVCSecKey,
+		ProviderURL:       c.ProviderURL,
+		KeyID:             userData.KeyID,
+		Salt:              userData.Salt,
+		Email:             userData.Email,
+		DisplayMap:        displayConf,
+		PreviewDisplayMap: previewDisplayConf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message pack payload: %w", err)
+	}
+
+	signed, err := joseSign(payloadBytes, c.SigningKey, userData.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message pack: %w", err)
+	}
+
+	return []byte(signed), nil
+}
+
+// ParseMessagePack is PrepareMessagePack's recipient-side counterpart: it
+// verifies the JWS against issuerPubKey, then decrypts the VC secret key
+// JWE via unwrapVCSecKey (the wallet provider's callback, which holds the
+// private key matching the WpPubKey the JWE was sealed to) and uses the
+// recovered key to decrypt the credential JWE.
+func ParseMessagePack(messagePack []byte, issuerPubKey jwk.Key, unwrapVCSecKey func(encVCSecKey string) (jwk.Key, error)) ([]byte, jwk.Key, error) {
+	payloadBytes, err := joseVerify(messagePack, issuerPubKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify message pack: %w", err)
+	}
+
+	var payload josePayload
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse message pack payload: %w", err)
+	}
+
+	vcSecKey, err := unwrapVCSecKey(payload.EncVCSecKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unwrap VC secret key: %w", err)
+	}
+
+	vc, err := joseDecrypt(payload.EncVC, vcSecKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+
+	return vc, vcSecKey, nil
+}
+
+// joseEncrypt JWE-encrypts plaintext to recipientJWK's ECDSA public key
+// using ECDH-ES+A256KW key wrap and A256GCM content encryption, returning
+// the compact serialization.
+func joseEncrypt(plaintext []byte, recipientJWK jwk.Key) (string, error) {
+	var recipientPub ecdsa.PublicKey
+	if err := recipientJWK.Raw(&recipientPub); err != nil {
+		return "", fmt.Errorf("recipient key is not an ECDSA public key: %w", err)
+	}
+
+	encrypter, err := jose.NewEncrypter(jose.A256GCM, jose.Recipient{
+		Algorithm: jose.ECDH_ES_A256KW,
+		Key:       &recipientPub,
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build JWE encrypter: %w", err)
+	}
+
+	object, err := encrypter.Encrypt(plaintext)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt JWE: %w", err)
+	}
+
+	return object.CompactSerialize()
+}
+
+// joseDecrypt opens a compact JWE produced by joseEncrypt using
+// recipientKey's ECDSA private key.
+func joseDecrypt(compact string, recipientKey jwk.Key) ([]byte, error) {
+	var recipientPriv ecdsa.PrivateKey
+	if err := recipientKey.Raw(&recipientPriv); err != nil {
+		return nil, fmt.Errorf("key is not an ECDSA private key: %w", err)
+	}
+
+	object, err := jose.ParseEncrypted(compact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWE: %w", err)
+	}
+
+	plaintext, err := object.Decrypt(&recipientPriv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt JWE: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// joseSign signs payload as a compact JWS using signingKey's ECDSA private
+// key (ES256), setting the kid header to kid.
+func joseSign(payload []byte, signingKey jwk.Key, kid string) (string, error) {
+	var priv ecdsa.PrivateKey
+	if err := signingKey.Raw(&priv); err != nil {
+		return "", fmt.Errorf("signing key is not an ECDSA private key: %w", err)
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: &priv},
+		(&jose.SignerOptions{}).WithHeader("kid", kid),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to build JWS signer: %w", err)
+	}
+
+	object, err := signer.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWS: %w", err)
+	}
+
+	return object.CompactSerialize()
+}
+
+// joseVerify verifies a compact JWS produced by joseSign against pubKey's
+// ECDSA public key and returns the signed payload.
+func joseVerify(compact []byte, pubKey jwk.Key) ([]byte, error) {
+	var pub ecdsa.PublicKey
+	if err := pubKey.Raw(&pub); err != nil {
+		return nil, fmt.Errorf("key is not an ECDSA public key: %w", err)
+	}
+
+	object, err := jose.ParseSigned(string(compact))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JWS: %w", err)
+	}
+
+	payload, err := object.Verify(&pub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify JWS signature: %w", err)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,149 @@
+package cvc
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func TestPrepareAndParseMessagePackRoundTrip(t *testing.T) {
+	issuerSigningKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate issuer signing key: %v", err)
+	}
+	issuerPubKey, err := issuerSigningKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive issuer public key: %v", err)
+	}
+
+	vcSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate VC secret key: %v", err)
+	}
+	vcPubKey, err := vcSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive VC public key: %v", err)
+	}
+
+	wpSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate wallet provider secret key: %v", err)
+	}
+	wpPubKey, err := wpSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive wallet provider public key: %v", err)
+	}
+
+	uuid := "user-1"
+	userMap := map[string]*UserData{
+		uuid: {
+			Email:    "user-1@example.com",
+			KeyID:    "key-1",
+			Salt:     []byte("salt"),
+			WpPubKey: wpPubKey,
+			VcSecKey: vcSecKey,
+			VcPubKey: vcPubKey,
+		},
+	}
+
+	issuer := &IssuerConfig{ProviderURL: "https://wp.example.com", SigningKey: issuerSigningKey}
+
+	signedCredential := []byte("the signed credential bytes")
+	messagePack, err := issuer.PrepareMessagePack(context.Background(), signedCredential, uuid, userMap, []byte("display"), []byte("preview"))
+	if err != nil {
+		t.Fatalf("PrepareMessagePack returned an error: %v", err)
+	}
+
+	unwrapVCSecKey := func(encVCSecKey string) (jwk.Key, error) {
+		return joseDecryptToKey(encVCSecKey, wpSecKey)
+	}
+
+	vc, recoveredVcSecKey, err := ParseMessagePack(messagePack, issuerPubKey, unwrapVCSecKey)
+	if err != nil {
+		t.Fatalf("ParseMessagePack returned an error: %v", err)
+	}
+
+	if !bytes.Equal(vc, signedCredential) {
+		t.Fatalf("decrypted credential %q does not match original %q", vc, signedCredential)
+	}
+
+	if recoveredVcSecKey.KeyType() != vcSecKey.KeyType() {
+		t.Fatalf("recovered VC secret key type %q does not match original %q", recoveredVcSecKey.KeyType(), vcSecKey.KeyType())
+	}
+}
+
+func TestParseMessagePackRejectsTamperedSignature(t *testing.T) {
+	issuerSigningKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate issuer signing key: %v", err)
+	}
+
+	otherSigningKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate unrelated signing key: %v", err)
+	}
+	otherPubKey, err := otherSigningKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive unrelated public key: %v", err)
+	}
+
+	vcSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate VC secret key: %v", err)
+	}
+	vcPubKey, err := vcSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive VC public key: %v", err)
+	}
+
+	wpSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate wallet provider secret key: %v", err)
+	}
+	wpPubKey, err := wpSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive wallet provider public key: %v", err)
+	}
+
+	uuid := "user-1"
+	userMap := map[string]*UserData{
+		uuid: {
+			Email:    "user-1@example.com",
+			KeyID:    "key-1",
+			Salt:     []byte("salt"),
+			WpPubKey: wpPubKey,
+			VcSecKey: vcSecKey,
+			VcPubKey: vcPubKey,
+		},
+	}
+
+	issuer := &IssuerConfig{ProviderURL: "https://wp.example.com", SigningKey: issuerSigningKey}
+
+	messagePack, err := issuer.PrepareMessagePack(context.Background(), []byte("the signed credential bytes"), uuid, userMap, nil, nil)
+	if err != nil {
+		t.Fatalf("PrepareMessagePack returned an error: %v", err)
+	}
+
+	unwrapVCSecKey := func(encVCSecKey string) (jwk.Key, error) {
+		return joseDecryptToKey(encVCSecKey, wpSecKey)
+	}
+
+	// otherPubKey did not sign this message pack, so verification must fail
+	// before any decryption is attempted.
+	if _, _, err := ParseMessagePack(messagePack, otherPubKey, unwrapVCSecKey); err == nil {
+		t.Fatalf("expected ParseMessagePack to reject a signature from an unrelated key")
+	}
+}
+
+// joseDecryptToKey decrypts a JWE-wrapped VC secret key with recipientKey
+// and parses the result back into a jwk.Key, the shape a wallet provider's
+// unwrapVCSecKey callback returns.
+func joseDecryptToKey(compact string, recipientKey jwk.Key) (jwk.Key, error) {
+	plaintext, err := joseDecrypt(compact, recipientKey)
+	if err != nil {
+		return nil, err
+	}
+	return jwk.ParseKey(plaintext)
+}
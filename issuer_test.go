@@ -0,0 +1,143 @@
+package cvc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"testing"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/MyNextID/cvc-go/pkg"
+)
+
+func TestAddCnfToPayloadSetsConfirmationKeyID(t *testing.T) {
+	wpSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate wallet provider secret key: %v", err)
+	}
+	wpPubKey, err := wpSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive wallet provider public key: %v", err)
+	}
+
+	uuid := "user-1"
+	userMap := map[string]*UserData{
+		uuid: {Email: "user-1@example.com", WpPubKey: wpPubKey},
+	}
+
+	issuer := &IssuerConfig{}
+
+	vcPayload, userData, err := issuer.AddCnfToPayload(context.Background(), uuid, map[string]interface{}{}, userMap)
+	if err != nil {
+		t.Fatalf("AddCnfToPayload returned an error: %v", err)
+	}
+
+	if userData.ConfirmationKeyID == "" {
+		t.Fatalf("expected ConfirmationKeyID to be set")
+	}
+
+	kid, ok := vcPayload["kid"].(string)
+	if !ok || kid == "" {
+		t.Fatalf("expected vcPayload[\"kid\"] to be a non-empty string, got %v", vcPayload["kid"])
+	}
+	if kid != userData.ConfirmationKeyID {
+		t.Fatalf("vcPayload kid %q does not match userData.ConfirmationKeyID %q", kid, userData.ConfirmationKeyID)
+	}
+
+	cnfKey, err := AddPublicKeys(userData.VcPubKey, userData.WpPubKey)
+	if err != nil {
+		t.Fatalf("failed to recompute confirmation key: %v", err)
+	}
+	wantKID, err := pkg.KeyFingerprint(cnfKey)
+	if err != nil {
+		t.Fatalf("pkg.KeyFingerprint returned an error: %v", err)
+	}
+	if kid != wantKID {
+		t.Fatalf("kid %q does not match the recomputed confirmation key fingerprint %q", kid, wantKID)
+	}
+}
+
+// TestAddCnfToPayloadNonP256Algorithm proves that when the issuer and the
+// wallet provider agree on a non-default curve up front (here P-384, via
+// IssuerConfig.Algorithm), AddCnfToPayload still produces a valid
+// confirmation key on that curve, rather than silently falling back to -
+// or erroring against - the module's historical P-256 default.
+func TestAddCnfToPayloadNonP256Algorithm(t *testing.T) {
+	wpSecKey, err := GenerateSecretKeyForCurve(internal.CurveP384)
+	if err != nil {
+		t.Fatalf("failed to generate P-384 wallet provider secret key: %v", err)
+	}
+	wpPubKey, err := wpSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive wallet provider public key: %v", err)
+	}
+
+	uuid := "user-1"
+	userMap := map[string]*UserData{
+		uuid: {Email: "user-1@example.com", WpPubKey: wpPubKey},
+	}
+
+	issuer := &IssuerConfig{Algorithm: AlgorithmP384}
+
+	_, userData, err := issuer.AddCnfToPayload(context.Background(), uuid, map[string]interface{}{}, userMap)
+	if err != nil {
+		t.Fatalf("AddCnfToPayload returned an error: %v", err)
+	}
+
+	cnfKey, err := AddPublicKeys(userData.VcPubKey, userData.WpPubKey)
+	if err != nil {
+		t.Fatalf("failed to recompute confirmation key: %v", err)
+	}
+
+	var rawCnfKey ecdsa.PublicKey
+	if err := cnfKey.Raw(&rawCnfKey); err != nil {
+		t.Fatalf("confirmation key is not an ECDSA public key: %v", err)
+	}
+	if rawCnfKey.Curve != elliptic.P384() {
+		t.Fatalf("expected the confirmation key to be on P-384, got %v", rawCnfKey.Curve.Params().Name)
+	}
+}
+
+// TestAddCnfToPayloadSecp256k1Algorithm is TestAddCnfToPayloadNonP256Algorithm
+// for secp256k1: it pins the full issuer/wallet-provider handshake to real
+// curve arithmetic, since secp256k1 once reached a CurveParams registration
+// whose a=-3 math doesn't hold for its a=0 curve and panicked on every
+// ScalarBaseMult instead of just producing an invalid confirmation key.
+func TestAddCnfToPayloadSecp256k1Algorithm(t *testing.T) {
+	wpSecKey, err := GenerateSecretKeyForCurve(internal.CurveSecp256k1)
+	if err != nil {
+		t.Fatalf("failed to generate secp256k1 wallet provider secret key: %v", err)
+	}
+	wpPubKey, err := wpSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive wallet provider public key: %v", err)
+	}
+
+	uuid := "user-1"
+	userMap := map[string]*UserData{
+		uuid: {Email: "user-1@example.com", WpPubKey: wpPubKey},
+	}
+
+	issuer := &IssuerConfig{Algorithm: AlgorithmSecp256k1}
+
+	_, userData, err := issuer.AddCnfToPayload(context.Background(), uuid, map[string]interface{}{}, userMap)
+	if err != nil {
+		t.Fatalf("AddCnfToPayload returned an error: %v", err)
+	}
+
+	cnfKey, err := AddPublicKeys(userData.VcPubKey, userData.WpPubKey)
+	if err != nil {
+		t.Fatalf("failed to recompute confirmation key: %v", err)
+	}
+
+	var rawCnfKey ecdsa.PublicKey
+	if err := cnfKey.Raw(&rawCnfKey); err != nil {
+		t.Fatalf("confirmation key is not an ECDSA public key: %v", err)
+	}
+	if rawCnfKey.Curve.Params().Name != "secp256k1" {
+		t.Fatalf("expected the confirmation key to be on secp256k1, got %v", rawCnfKey.Curve.Params().Name)
+	}
+	if !rawCnfKey.Curve.IsOnCurve(rawCnfKey.X, rawCnfKey.Y) {
+		t.Fatalf("expected the confirmation key point to be on secp256k1")
+	}
+}
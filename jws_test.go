@@ -0,0 +1,59 @@
+package cvc
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	secretKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+
+	publicKey, err := secretKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+
+	payload := []byte("cvc signed payload")
+
+	signed, err := SignWithSecretKey(payload, secretKey, "")
+	if err != nil {
+		t.Fatalf("SignWithSecretKey returned an error: %v", err)
+	}
+
+	verified, err := VerifyWithPublicKey(signed, publicKey)
+	if err != nil {
+		t.Fatalf("VerifyWithPublicKey returned an error: %v", err)
+	}
+
+	if !bytes.Equal(verified, payload) {
+		t.Fatalf("verified payload %q does not match original %q", verified, payload)
+	}
+}
+
+func TestVerifyWithPublicKeyRejectsTamperedPayload(t *testing.T) {
+	secretKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+	publicKey, err := secretKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+
+	otherSecretKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+
+	signed, err := SignWithSecretKey([]byte("cvc signed payload"), otherSecretKey, "")
+	if err != nil {
+		t.Fatalf("SignWithSecretKey returned an error: %v", err)
+	}
+
+	if _, err := VerifyWithPublicKey(signed, publicKey); err == nil {
+		t.Fatalf("expected verification with the wrong public key to fail")
+	}
+}
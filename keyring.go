@@ -0,0 +1,205 @@
+package cvc
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// masterKeyVersionField is the private JWK parameter a KeyRing tags its
+// derived keys with, so downstream verifiers can look up the exact master
+// key version a child key came from instead of trying every known public
+// key in turn.
+const masterKeyVersionField = "master_version"
+
+// MasterKeyStatus reports whether a KeyRing version may still be used to
+// derive brand-new child keys, only to reproduce legacy ones, or not at
+// all, mirroring Vault transit's min_encryption_version/
+// min_decryption_version scheme.
+type MasterKeyStatus string
+
+const (
+	// MasterKeyActive is the ring's current version: used for both new
+	// derivations and reproducing historical ones.
+	MasterKeyActive MasterKeyStatus = "active"
+	// MasterKeyDeprecated versions are at or above MinDecryptionVersion:
+	// DeriveSecretKeyAt still reproduces derivations made under them, but
+	// Rotate has moved new derivations to a newer version.
+	MasterKeyDeprecated MasterKeyStatus = "deprecated"
+	// MasterKeyArchived versions are below MinDecryptionVersion and
+	// refuse every derivation, including DeriveSecretKeyAt.
+	MasterKeyArchived MasterKeyStatus = "archived"
+)
+
+// MasterKeyVersion is one master key generation in a KeyRing.
+type MasterKeyVersion struct {
+	Version   uint32
+	MasterKey jwk.Key
+	CreatedAt time.Time
+}
+
+// MarshalJSON encodes v with MasterKey as its underlying JWK JSON so a
+// KeyRing round-trips through JSON without a custom key-by-key encoder.
+func (v MasterKeyVersion) MarshalJSON() ([]byte, error) {
+	keyJSON, err := json.Marshal(v.MasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal master key version %d: %w", v.Version, err)
+	}
+
+	return json.Marshal(struct {
+		Version   uint32          `json:"version"`
+		MasterKey json.RawMessage `json:"master_key"`
+		CreatedAt time.Time       `json:"created_at"`
+	}{Version: v.Version, MasterKey: keyJSON, CreatedAt: v.CreatedAt})
+}
+
+// UnmarshalJSON is the inverse of MarshalJSON, parsing MasterKey back into
+// a jwk.Key.
+func (v *MasterKeyVersion) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Version   uint32          `json:"version"`
+		MasterKey json.RawMessage `json:"master_key"`
+		CreatedAt time.Time       `json:"created_at"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to unmarshal master key version: %w", err)
+	}
+
+	key, err := jwk.ParseKey(raw.MasterKey)
+	if err != nil {
+		return fmt.Errorf("failed to parse master key for version %d: %w", raw.Version, err)
+	}
+
+	v.Version = raw.Version
+	v.MasterKey = key
+	v.CreatedAt = raw.CreatedAt
+	return nil
+}
+
+// KeyRing holds every master key version a provider has ever derived
+// child keys from, so old derivations stay reproducible across rotation.
+// MinEncryptionVersion/MinDecryptionVersion mirror Vault transit's scheme:
+// new derivations always use CurrentVersion, and versions below
+// MinDecryptionVersion are archived and refuse every derivation.
+type KeyRing struct {
+	Versions             []MasterKeyVersion
+	CurrentVersion       uint32
+	MinEncryptionVersion uint32
+	MinDecryptionVersion uint32
+	Curve                internal.Curve
+}
+
+// NewKeyRing creates a KeyRing around a freshly generated master key
+// version 1 on curve.
+func NewKeyRing(curve internal.Curve) (*KeyRing, error) {
+	masterKey, err := GenerateSecretKeyForCurve(curve)
+	if err != nil {
+		return nil, internal.WrapError(err, "failed to generate initial master key")
+	}
+
+	version := MasterKeyVersion{Version: 1, MasterKey: masterKey, CreatedAt: time.Now().UTC()}
+
+	return &KeyRing{
+		Versions:             []MasterKeyVersion{version},
+		CurrentVersion:       1,
+		MinEncryptionVersion: 1,
+		MinDecryptionVersion: 1,
+		Curve:                curve,
+	}, nil
+}
+
+// Rotate generates a new master key version and makes it current. The
+// previously current version becomes MasterKeyDeprecated: DeriveSecretKeyAt
+// can still reproduce derivations made under it, but DeriveSecretKey no
+// longer uses it for new ones.
+func (r *KeyRing) Rotate() (*MasterKeyVersion, error) {
+	masterKey, err := GenerateSecretKeyForCurve(r.Curve)
+	if err != nil {
+		return nil, internal.WrapError(err, "failed to generate rotated master key")
+	}
+
+	next := MasterKeyVersion{Version: r.CurrentVersion + 1, MasterKey: masterKey, CreatedAt: time.Now().UTC()}
+	r.Versions = append(r.Versions, next)
+	r.CurrentVersion = next.Version
+	r.MinEncryptionVersion = next.Version
+
+	return &r.Versions[len(r.Versions)-1], nil
+}
+
+// Status reports whether version is active, deprecated, or archived.
+func (r *KeyRing) Status(version uint32) MasterKeyStatus {
+	switch {
+	case version < r.MinDecryptionVersion:
+		return MasterKeyArchived
+	case version == r.CurrentVersion:
+		return MasterKeyActive
+	default:
+		return MasterKeyDeprecated
+	}
+}
+
+func (r *KeyRing) version(version uint32) (*MasterKeyVersion, error) {
+	for i := range r.Versions {
+		if r.Versions[i].Version == version {
+			return &r.Versions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("key ring has no master key version %d", version)
+}
+
+// DeriveSecretKey derives a child key under the ring's current master key
+// version, the version every new derivation uses until the next Rotate.
+func (r *KeyRing) DeriveSecretKey(context, dst []byte) (jwk.Key, error) {
+	return r.DeriveSecretKeyAt(r.CurrentVersion, context, dst)
+}
+
+// DeriveSecretKeyAt reproduces the child key derivation done against a
+// specific historical master key version. It fails if version is unknown
+// to the ring or has been archived (below MinDecryptionVersion). The
+// returned key is tagged with its master key version so a verifier can
+// look up the matching public key directly.
+func (r *KeyRing) DeriveSecretKeyAt(version uint32, context, dst []byte) (jwk.Key, error) {
+	if r.Status(version) == MasterKeyArchived {
+		return nil, fmt.Errorf("master key version %d has been archived and can no longer be used for derivation", version)
+	}
+
+	masterVersion, err := r.version(version)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := DeriveSecretKey(masterVersion.MasterKey, context, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := key.Set(masterKeyVersionField, version); err != nil {
+		return nil, fmt.Errorf("failed to tag derived key with master key version: %w", err)
+	}
+
+	return key, nil
+}
+
+// MasterKeyVersionOf returns the master key version key was tagged with by
+// KeyRing.DeriveSecretKey/DeriveSecretKeyAt, and false if key carries no
+// such tag.
+func MasterKeyVersionOf(key jwk.Key) (uint32, bool) {
+	value, ok := key.Get(masterKeyVersionField)
+	if !ok {
+		return 0, false
+	}
+
+	switch version := value.(type) {
+	case uint32:
+		return version, true
+	case float64:
+		// A key round-tripped through JSON decodes its private parameters as
+		// float64, since Go's encoding/json has no integer type to target.
+		return uint32(version), true
+	}
+
+	return 0, false
+}
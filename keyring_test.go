@@ -0,0 +1,133 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+
+	"github.com/MyNextID/cvc-go/internal"
+)
+
+func extractScalarForTest(t *testing.T, key interface{ Raw(interface{}) error }) *ecdsa.PrivateKey {
+	t.Helper()
+	var privateKey ecdsa.PrivateKey
+	if err := key.Raw(&privateKey); err != nil {
+		t.Fatalf("failed to extract private key: %v", err)
+	}
+	return &privateKey
+}
+
+func TestKeyRingRotatePreservesHistoricalDerivation(t *testing.T) {
+	ring, err := NewKeyRing(internal.CurveP256)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned an error: %v", err)
+	}
+
+	context := []byte("attribute-1")
+	dst := []byte("CVC-TEST-DST-v1.0")
+
+	beforeRotate, err := ring.DeriveSecretKey(context, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKey returned an error: %v", err)
+	}
+
+	if _, err := ring.Rotate(); err != nil {
+		t.Fatalf("Rotate returned an error: %v", err)
+	}
+
+	replay, err := ring.DeriveSecretKeyAt(1, context, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKeyAt(1, ...) returned an error: %v", err)
+	}
+
+	before := extractScalarForTest(t, beforeRotate)
+	after := extractScalarForTest(t, replay)
+	if before.D.Cmp(after.D) != 0 {
+		t.Fatalf("rotation broke reproducibility of version 1's derivation")
+	}
+
+	version, ok := MasterKeyVersionOf(replay)
+	if !ok || version != 1 {
+		t.Fatalf("MasterKeyVersionOf(replay) = (%v, %v), want (1, true)", version, ok)
+	}
+
+	newDerivation, err := ring.DeriveSecretKey(context, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKey after rotate returned an error: %v", err)
+	}
+	if newVersion, _ := MasterKeyVersionOf(newDerivation); newVersion != 2 {
+		t.Fatalf("post-rotation derivation tagged with version %d, want 2", newVersion)
+	}
+
+	if status := ring.Status(1); status != MasterKeyDeprecated {
+		t.Errorf("Status(1) = %q, want %q", status, MasterKeyDeprecated)
+	}
+	if status := ring.Status(2); status != MasterKeyActive {
+		t.Errorf("Status(2) = %q, want %q", status, MasterKeyActive)
+	}
+}
+
+func TestKeyRingRefusesDerivationAgainstArchivedVersion(t *testing.T) {
+	ring, err := NewKeyRing(internal.CurveP256)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned an error: %v", err)
+	}
+
+	if _, err := ring.Rotate(); err != nil {
+		t.Fatalf("Rotate returned an error: %v", err)
+	}
+	ring.MinDecryptionVersion = 2
+
+	if status := ring.Status(1); status != MasterKeyArchived {
+		t.Fatalf("Status(1) = %q, want %q", status, MasterKeyArchived)
+	}
+
+	if _, err := ring.DeriveSecretKeyAt(1, []byte("ctx"), []byte("CVC-TEST-DST-v1.0")); err == nil {
+		t.Fatalf("expected DeriveSecretKeyAt to refuse an archived version")
+	}
+}
+
+func TestKeyRingJSONRoundTrip(t *testing.T) {
+	ring, err := NewKeyRing(internal.CurveP256)
+	if err != nil {
+		t.Fatalf("NewKeyRing returned an error: %v", err)
+	}
+	if _, err := ring.Rotate(); err != nil {
+		t.Fatalf("Rotate returned an error: %v", err)
+	}
+
+	data, err := json.Marshal(ring)
+	if err != nil {
+		t.Fatalf("failed to marshal key ring: %v", err)
+	}
+
+	var restored KeyRing
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("failed to unmarshal key ring: %v", err)
+	}
+
+	if restored.CurrentVersion != ring.CurrentVersion {
+		t.Errorf("CurrentVersion = %d, want %d", restored.CurrentVersion, ring.CurrentVersion)
+	}
+	if len(restored.Versions) != len(ring.Versions) {
+		t.Fatalf("expected %d versions, got %d", len(ring.Versions), len(restored.Versions))
+	}
+
+	context := []byte("attribute-1")
+	dst := []byte("CVC-TEST-DST-v1.0")
+
+	original, err := ring.DeriveSecretKeyAt(1, context, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKeyAt on original ring failed: %v", err)
+	}
+	roundTripped, err := restored.DeriveSecretKeyAt(1, context, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKeyAt on restored ring failed: %v", err)
+	}
+
+	originalScalar := extractScalarForTest(t, original)
+	roundTrippedScalar := extractScalarForTest(t, roundTripped)
+	if originalScalar.D.Cmp(roundTrippedScalar.D) != 0 {
+		t.Fatalf("key ring JSON round-trip lost the ability to reproduce version 1's derivation")
+	}
+}
@@ -0,0 +1,254 @@
+// Package keystore provides a production-ready cvc.MasterKeyStore backed by
+// an encrypted file on disk, so a deployment doesn't have to write its own
+// persistence just to survive a process restart. The master JWK is stored
+// in a JWE-flavored envelope - AES-256-GCM under a key derived from a user
+// passphrase via PBKDF2-HMAC-SHA256 - mirroring what libtrust's key_files
+// did, but authenticated rather than a bare encrypted blob.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/MyNextID/cvc-go/pkg"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// DefaultPBKDF2Iterations is the PBKDF2-HMAC-SHA256 iteration count used
+// whenever a FileMasterKeyStore's Iterations field (or an explicit
+// iterations argument) is left at zero, per current OWASP password storage
+// guidance.
+const DefaultPBKDF2Iterations = 600_000
+
+// envelopeAlg labels the envelope format on disk. It is not a standalone
+// JOSE "alg" a general-purpose JWE library would recognize; this package
+// predates any need for interop and reads/writes its own envelope.
+const envelopeAlg = "PBES2-HS256+A128KW"
+
+// envelope is the on-disk JSON wrapper around an encrypted master JWK: a
+// PBKDF2 salt (P2S) and iteration count (P2C), the AES-GCM nonce (IV), and
+// the ciphertext/tag split the way a JWE would present them. All byte
+// fields marshal as standard base64 via encoding/json's []byte support.
+type envelope struct {
+	Alg string `json:"alg"`
+	P2S []byte `json:"p2s"`
+	P2C int    `json:"p2c"`
+	IV  []byte `json:"iv"`
+	CT  []byte `json:"ct"`
+	Tag []byte `json:"tag"`
+}
+
+const (
+	saltSize  = 16
+	gcmTagLen = 16
+)
+
+// FileMasterKeyStore is a cvc.MasterKeyStore that reads the master JWK from
+// an encrypted file at Path, asking PassphraseProvider for the decryption
+// passphrase on every call rather than holding it in memory.
+type FileMasterKeyStore struct {
+	Path               string
+	PassphraseProvider func() ([]byte, error)
+	// Iterations is the PBKDF2-HMAC-SHA256 iteration count used the next
+	// time this store writes the file (via Rotate). It has no effect on
+	// reading: GetMasterKey always uses the P2C stored in the envelope.
+	// Zero uses DefaultPBKDF2Iterations.
+	Iterations int
+}
+
+// NewFileMasterKeyStore returns a FileMasterKeyStore reading the encrypted
+// master key at path, obtaining the passphrase from passphraseProvider each
+// time GetMasterKey or Rotate is called.
+func NewFileMasterKeyStore(path string, passphraseProvider func() ([]byte, error)) *FileMasterKeyStore {
+	return &FileMasterKeyStore{Path: path, PassphraseProvider: passphraseProvider}
+}
+
+// GetMasterKey implements cvc.MasterKeyStore: it reads and decrypts the
+// envelope at s.Path and parses the result as a JWK.
+func (s *FileMasterKeyStore) GetMasterKey() (jwk.Key, error) {
+	plaintext, _, err := s.readAndOpen()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := pkg.JsonToJWK(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to parse decrypted master key: %w", err)
+	}
+
+	return key, nil
+}
+
+// Rotate re-encrypts the stored master key under newPassphrase and replaces
+// s.Path atomically, so a crash mid-write can never leave a corrupt or
+// half-written envelope on disk. The new envelope uses s.Iterations (or
+// DefaultPBKDF2Iterations, if unset) and a fresh salt and nonce.
+func (s *FileMasterKeyStore) Rotate(newPassphrase []byte) error {
+	plaintext, _, err := s.readAndOpen()
+	if err != nil {
+		return err
+	}
+
+	if err := writeSealed(s.Path, plaintext, newPassphrase, s.iterations()); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileMasterKeyStore) iterations() int {
+	if s.Iterations > 0 {
+		return s.Iterations
+	}
+	return DefaultPBKDF2Iterations
+}
+
+func (s *FileMasterKeyStore) readAndOpen() ([]byte, envelope, error) {
+	raw, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, envelope{}, fmt.Errorf("keystore: failed to read %s: %w", s.Path, err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, envelope{}, fmt.Errorf("keystore: failed to parse envelope in %s: %w", s.Path, err)
+	}
+
+	passphrase, err := s.PassphraseProvider()
+	if err != nil {
+		return nil, envelope{}, fmt.Errorf("keystore: failed to obtain passphrase: %w", err)
+	}
+
+	plaintext, err := open(env, passphrase)
+	if err != nil {
+		return nil, envelope{}, err
+	}
+
+	return plaintext, env, nil
+}
+
+// WriteMasterKey seals masterKey to path under passphrase, creating the
+// file (0600 permissions) via a temp-file-plus-rename so a crash mid-write
+// never leaves a corrupt file at path. iterations of 0 uses
+// DefaultPBKDF2Iterations. Use this once, up front, to provision the file a
+// FileMasterKeyStore will later read.
+func WriteMasterKey(path string, masterKey jwk.Key, passphrase []byte, iterations int) error {
+	plaintext, err := pkg.JWKToJson(masterKey)
+	if err != nil {
+		return fmt.Errorf("keystore: failed to marshal master key: %w", err)
+	}
+
+	if iterations <= 0 {
+		iterations = DefaultPBKDF2Iterations
+	}
+
+	return writeSealed(path, plaintext, passphrase, iterations)
+}
+
+func writeSealed(path string, plaintext, passphrase []byte, iterations int) error {
+	env, err := seal(plaintext, passphrase, iterations)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("keystore: failed to marshal envelope: %w", err)
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("keystore: failed to create temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("keystore: failed to set permissions on %s: %w", tmpPath, err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("keystore: failed to write %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("keystore: failed to close %s: %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("keystore: failed to rename %s to %s: %w", tmpPath, path, err)
+	}
+
+	return nil
+}
+
+// seal encrypts plaintext under a PBKDF2-HMAC-SHA256-derived AES-256-GCM key.
+func seal(plaintext, passphrase []byte, iterations int) (*envelope, error) {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("keystore: failed to generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("keystore: failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nil, nonce, plaintext, nil)
+	ct, tag := sealed[:len(sealed)-gcmTagLen], sealed[len(sealed)-gcmTagLen:]
+
+	return &envelope{
+		Alg: envelopeAlg,
+		P2S: salt,
+		P2C: iterations,
+		IV:  nonce,
+		CT:  ct,
+		Tag: tag,
+	}, nil
+}
+
+// open decrypts an envelope produced by seal.
+func open(env envelope, passphrase []byte) ([]byte, error) {
+	gcm, err := newGCM(passphrase, env.P2S, env.P2C)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed := append(append([]byte{}, env.CT...), env.Tag...)
+
+	plaintext, err := gcm.Open(nil, env.IV, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to decrypt envelope: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	key := pbkdf2.Key(passphrase, salt, iterations, 32, sha256.New)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to build AES cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: failed to build AES-GCM: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,110 @@
+package keystore
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func staticPassphrase(passphrase string) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		return []byte(passphrase), nil
+	}
+}
+
+func generateTestMasterKey(t *testing.T) jwk.Key {
+	t.Helper()
+
+	rawKey, err := jwk.FromRaw([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatalf("failed to build test master key: %v", err)
+	}
+
+	return rawKey
+}
+
+func TestFileMasterKeyStoreRoundTrip(t *testing.T) {
+	masterKey := generateTestMasterKey(t)
+	path := filepath.Join(t.TempDir(), "master.key")
+
+	if err := WriteMasterKey(path, masterKey, []byte("correct horse battery staple"), 1000); err != nil {
+		t.Fatalf("WriteMasterKey returned an error: %v", err)
+	}
+
+	store := NewFileMasterKeyStore(path, staticPassphrase("correct horse battery staple"))
+
+	got, err := store.GetMasterKey()
+	if err != nil {
+		t.Fatalf("GetMasterKey returned an error: %v", err)
+	}
+
+	var gotRaw, wantRaw []byte
+	if err := got.Raw(&gotRaw); err != nil {
+		t.Fatalf("failed to extract decrypted key bytes: %v", err)
+	}
+	if err := masterKey.Raw(&wantRaw); err != nil {
+		t.Fatalf("failed to extract original key bytes: %v", err)
+	}
+	if string(gotRaw) != string(wantRaw) {
+		t.Fatalf("decrypted key bytes %q do not match original %q", gotRaw, wantRaw)
+	}
+}
+
+func TestFileMasterKeyStoreRejectsWrongPassphrase(t *testing.T) {
+	masterKey := generateTestMasterKey(t)
+	path := filepath.Join(t.TempDir(), "master.key")
+
+	if err := WriteMasterKey(path, masterKey, []byte("correct horse battery staple"), 1000); err != nil {
+		t.Fatalf("WriteMasterKey returned an error: %v", err)
+	}
+
+	store := NewFileMasterKeyStore(path, staticPassphrase("wrong passphrase"))
+
+	if _, err := store.GetMasterKey(); err == nil {
+		t.Fatalf("expected GetMasterKey to reject the wrong passphrase")
+	}
+}
+
+func TestFileMasterKeyStoreRotate(t *testing.T) {
+	masterKey := generateTestMasterKey(t)
+	path := filepath.Join(t.TempDir(), "master.key")
+
+	if err := WriteMasterKey(path, masterKey, []byte("old passphrase"), 1000); err != nil {
+		t.Fatalf("WriteMasterKey returned an error: %v", err)
+	}
+
+	store := &FileMasterKeyStore{
+		Path:               path,
+		PassphraseProvider: staticPassphrase("old passphrase"),
+		Iterations:         1000,
+	}
+
+	if err := store.Rotate([]byte("new passphrase")); err != nil {
+		t.Fatalf("Rotate returned an error: %v", err)
+	}
+
+	store.PassphraseProvider = staticPassphrase("old passphrase")
+	if _, err := store.GetMasterKey(); err == nil {
+		t.Fatalf("expected GetMasterKey to reject the pre-rotation passphrase")
+	}
+
+	store.PassphraseProvider = staticPassphrase("new passphrase")
+	if _, err := store.GetMasterKey(); err != nil {
+		t.Fatalf("GetMasterKey returned an error after rotation: %v", err)
+	}
+}
+
+func TestWriteMasterKeyDefaultsIterations(t *testing.T) {
+	masterKey := generateTestMasterKey(t)
+	path := filepath.Join(t.TempDir(), "master.key")
+
+	if err := WriteMasterKey(path, masterKey, []byte("passphrase"), 0); err != nil {
+		t.Fatalf("WriteMasterKey returned an error: %v", err)
+	}
+
+	store := NewFileMasterKeyStore(path, staticPassphrase("passphrase"))
+	if _, err := store.GetMasterKey(); err != nil {
+		t.Fatalf("GetMasterKey returned an error: %v", err)
+	}
+}
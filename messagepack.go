@@ -0,0 +1,237 @@
+package cvc
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/MyNextID/cvc-go/pkg"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/shamaton/msgpack/v2"
+	"golang.org/x/crypto/hkdf"
+)
+
+// HKDF info tags separating the two envelopes F2 produces, so a key derived
+// for one can never be reused to open the other even if an ephemeral key
+// were (against the odds) reused across both.
+const (
+	vcContextTag       = "vc"
+	vcSecKeyContextTag = "vc-sec-key"
+)
+
+// envelope is the wire format sealEnvelope/openEnvelope produce: an
+// ephemeral public key on the recipient's curve, the AES-GCM nonce, and the
+// sealed ciphertext with its authentication tag appended.
+type envelope struct {
+	EphemeralPublicKey []byte `msgpack:"ephemeral_public_key"`
+	Nonce              []byte `msgpack:"nonce"`
+	Ciphertext         []byte `msgpack:"ciphertext"`
+}
+
+// F2 seals a signed credential into a MessagePack for delivery to the
+// recipient's email: vcBytes is sealed to the recipient's VcPubKey, and the
+// VC secret key that opens it is sealed separately to the recipient's
+// WpPubKey, so only the wallet provider can ever release it. Each seal is
+// an independent ephemeral-ECDH/HKDF-SHA256/AES-256-GCM construction (see
+// sealEnvelope); F1 must already have populated userMap[uuid]'s VcPubKey.
+func (c *Config) F2(uuid string, vcBytes []byte, displayMap []byte, providerURL string, userMap map[string]*UserData) (*MessagePack, error) {
+	if uuid == "" {
+		return nil, internal.WrapError(internal.ErrEmptyUUID, "uuid cannot be empty")
+	}
+	if len(vcBytes) == 0 {
+		return nil, internal.WrapError(internal.ErrInvalidParameters, "vcBytes cannot be empty")
+	}
+
+	userData, ok := userMap[uuid]
+	if !ok {
+		return nil, internal.WrapError(internal.ErrUserNotFound, fmt.Sprintf("no user data for uuid %s", uuid))
+	}
+	if userData.VcPubKey == nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, "VC public key not set for user; call F1 first")
+	}
+	if userData.WpPubKey == nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, "wallet provider public key not set for user; call F0 first")
+	}
+	if c.Curve != CurveP256 {
+		// sealEnvelope/openEnvelope below only know how to do ECDH against
+		// an ecdsa.PublicKey; wiring F2/F3 up to the Curve25519 keys
+		// x25519.go produces is future work, not something this function
+		// does yet.
+		return nil, internal.WrapError(internal.ErrCurveUnsupported, fmt.Sprintf("F2 does not yet support curve %d", c.Curve))
+	}
+
+	vcEnvelope, err := sealEnvelope(userData.VcPubKey, vcBytes, vcContextTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal credential: %w", err)
+	}
+	encVC, err := msgpack.Marshal(vcEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credential envelope: %w", err)
+	}
+
+	vcSecKeyBytes, err := pkg.JWKToJson(userData.VcSecKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal VC secret key: %w", err)
+	}
+	vcSecKeyEnvelope, err := sealEnvelope(userData.WpPubKey, vcSecKeyBytes, vcSecKeyContextTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal credential secret key: %w", err)
+	}
+	encVCSecKey, err := msgpack.Marshal(vcSecKeyEnvelope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credential secret key envelope: %w", err)
+	}
+
+	return &MessagePack{
+		EncVC:       encVC,
+		EncVCSecKey: encVCSecKey,
+		ProviderURL: providerURL,
+		KeyId:       userData.KeyID,
+		Salt:        userData.Salt,
+		Email:       userData.Email,
+		DisplayMap:  displayMap,
+		Curve:       c.Curve,
+	}, nil
+}
+
+// F3 is the inverse of F2: it recovers the plaintext VC bytes sealed in
+// msg.EncVC. The VC secret key needed to open EncVC can come from either
+// side of the split it was sealed under: pass the wallet provider's
+// secret key as wpSecKey to have F3 recover it by opening EncVCSecKey, or
+// pass the already-recovered VC secret key directly as vcSecKey and leave
+// wpSecKey nil. If both are non-nil, wpSecKey's recovered key is used and
+// vcSecKey is ignored.
+func (c *Config) F3(msg *MessagePack, wpSecKey, vcSecKey jwk.Key) ([]byte, error) {
+	if msg == nil {
+		return nil, internal.WrapError(internal.ErrInvalidParameters, "message pack cannot be nil")
+	}
+
+	if wpSecKey != nil {
+		var vcSecKeyEnvelope envelope
+		if err := msgpack.Unmarshal(msg.EncVCSecKey, &vcSecKeyEnvelope); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal credential secret key envelope: %w", err)
+		}
+		vcSecKeyBytes, err := openEnvelope(wpSecKey, &vcSecKeyEnvelope, vcSecKeyContextTag)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open credential secret key: %w", err)
+		}
+		vcSecKey, err = pkg.JsonToJWK(vcSecKeyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse recovered VC secret key: %w", err)
+		}
+	}
+
+	if vcSecKey == nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, "either wpSecKey or vcSecKey must be provided")
+	}
+
+	var vcEnvelope envelope
+	if err := msgpack.Unmarshal(msg.EncVC, &vcEnvelope); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credential envelope: %w", err)
+	}
+
+	vcBytes, err := openEnvelope(vcSecKey, &vcEnvelope, vcContextTag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open credential: %w", err)
+	}
+
+	return vcBytes, nil
+}
+
+// sealEnvelope encrypts plaintext to recipientJWK (an ECDSA public key)
+// using an ECIES-style hybrid scheme: an ephemeral EC keypair on the
+// recipient's curve, ECDH to a shared secret, HKDF-SHA256 over that secret
+// with contextTag as the info parameter to derive a 32-byte AES-256 key,
+// and AES-256-GCM sealing with a random 12-byte nonce. This is the same
+// ephemeral-ECDH-plus-symmetric-wrap shape Hyperledger Fabric's BCCSP uses
+// for its AES key wrapping, but authenticated end to end by GCM instead of
+// relying on the transport for integrity.
+func sealEnvelope(recipientJWK jwk.Key, plaintext []byte, contextTag string) (*envelope, error) {
+	recipientPub, err := extractPublicKey(recipientJWK, "recipient key")
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralPriv, err := ecdsa.GenerateKey(recipientPub.Curve, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	sharedX, _ := recipientPub.Curve.ScalarMult(recipientPub.X, recipientPub.Y, ephemeralPriv.D.Bytes())
+
+	aead, err := newAEAD(sharedX.Bytes(), contextTag)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := aead.Seal(nil, nonce, plaintext, nil)
+
+	return &envelope{
+		EphemeralPublicKey: pkg.PublicECDSAToBytes(&ephemeralPriv.PublicKey),
+		Nonce:              nonce,
+		Ciphertext:         ciphertext,
+	}, nil
+}
+
+// openEnvelope decrypts env using recipientJWK's private key, the inverse
+// of sealEnvelope. aead.Open compares the authentication tag in constant
+// time and returns an error rather than any partial plaintext on mismatch.
+func openEnvelope(recipientJWK jwk.Key, env *envelope, contextTag string) ([]byte, error) {
+	recipientPriv, err := extractPrivateKey(recipientJWK, "recipient key")
+	if err != nil {
+		return nil, err
+	}
+
+	ephemeralPub, err := pkg.PublicBytesToECDSAOnCurve(env.EphemeralPublicKey, recipientPriv.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ephemeral public key: %w", err)
+	}
+	if err := validatePublicKey(ephemeralPub); err != nil {
+		return nil, fmt.Errorf("ephemeral public key is invalid: %w", err)
+	}
+
+	sharedX, _ := recipientPriv.Curve.ScalarMult(ephemeralPub.X, ephemeralPub.Y, recipientPriv.D.Bytes())
+
+	aead, err := newAEAD(sharedX.Bytes(), contextTag)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, env.Nonce, env.Ciphertext, nil)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrAuthentication, "envelope authentication failed")
+	}
+
+	return plaintext, nil
+}
+
+// newAEAD derives a 32-byte AES-256 key from sharedSecret via HKDF-SHA256
+// (info = contextTag) and returns the corresponding cipher.AEAD.
+func newAEAD(sharedSecret []byte, contextTag string) (cipher.AEAD, error) {
+	aesKey := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, nil, []byte(contextTag)), aesKey); err != nil {
+		return nil, fmt.Errorf("failed to derive AES key: %w", err)
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES-GCM AEAD: %w", err)
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,137 @@
+package cvc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/shamaton/msgpack/v2"
+)
+
+// newTestUserData builds a UserData with a fresh VC keypair and wallet
+// provider keypair, returning it alongside the wallet provider's secret key
+// (which, in the real pipeline, only the wallet provider ever holds).
+func newTestUserData(t *testing.T) (*UserData, jwk.Key) {
+	t.Helper()
+
+	vcSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate VC secret key: %v", err)
+	}
+	vcPubKey, err := vcSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive VC public key: %v", err)
+	}
+
+	wpSecKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate wallet provider secret key: %v", err)
+	}
+	wpPubKey, err := wpSecKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive wallet provider public key: %v", err)
+	}
+
+	return &UserData{
+		Email:    "user-1@example.com",
+		KeyID:    "key-1",
+		Salt:     []byte("salt"),
+		WpPubKey: wpPubKey,
+		VcSecKey: vcSecKey,
+		VcPubKey: vcPubKey,
+	}, wpSecKey
+}
+
+func TestConfigF2F3RoundTrip(t *testing.T) {
+	const uuid = "user-1"
+	userData, wpSecKey := newTestUserData(t)
+	userMap := map[string]*UserData{uuid: userData}
+	config := &Config{}
+
+	vcBytes := []byte("a signed verifiable credential")
+	msg, err := config.F2(uuid, vcBytes, []byte("display map"), "https://wp.example.com", userMap)
+	if err != nil {
+		t.Fatalf("F2 returned an error: %v", err)
+	}
+
+	// MessagePack must round-trip through msgpack, the format F2's caller
+	// actually transmits to the wallet.
+	msgBytes, err := msgpack.Marshal(msg)
+	if err != nil {
+		t.Fatalf("failed to marshal MessagePack: %v", err)
+	}
+	var decoded MessagePack
+	if err := msgpack.Unmarshal(msgBytes, &decoded); err != nil {
+		t.Fatalf("failed to unmarshal MessagePack: %v", err)
+	}
+
+	decrypted, err := config.F3(&decoded, wpSecKey, nil)
+	if err != nil {
+		t.Fatalf("F3 returned an error: %v", err)
+	}
+	if !bytes.Equal(decrypted, vcBytes) {
+		t.Fatalf("decrypted VC %q does not match original %q", decrypted, vcBytes)
+	}
+}
+
+func TestConfigF3WithRecoveredVcSecKey(t *testing.T) {
+	const uuid = "user-1"
+	userData, _ := newTestUserData(t)
+	userMap := map[string]*UserData{uuid: userData}
+	config := &Config{}
+
+	vcBytes := []byte("a signed verifiable credential")
+	msg, err := config.F2(uuid, vcBytes, nil, "https://wp.example.com", userMap)
+	if err != nil {
+		t.Fatalf("F2 returned an error: %v", err)
+	}
+
+	// Exercise the other calling convention: the caller already recovered
+	// the VC secret key (e.g. from the wallet provider, out of band) and
+	// passes it directly instead of wpSecKey.
+	decrypted, err := config.F3(msg, nil, userData.VcSecKey)
+	if err != nil {
+		t.Fatalf("F3 returned an error: %v", err)
+	}
+	if !bytes.Equal(decrypted, vcBytes) {
+		t.Fatalf("decrypted VC %q does not match original %q", decrypted, vcBytes)
+	}
+}
+
+func TestConfigF3RejectsTamperedCiphertext(t *testing.T) {
+	const uuid = "user-1"
+	userData, wpSecKey := newTestUserData(t)
+	userMap := map[string]*UserData{uuid: userData}
+	config := &Config{}
+
+	msg, err := config.F2(uuid, []byte("a signed verifiable credential"), nil, "https://wp.example.com", userMap)
+	if err != nil {
+		t.Fatalf("F2 returned an error: %v", err)
+	}
+
+	msg.EncVC[len(msg.EncVC)-1] ^= 0xFF
+
+	if _, err := config.F3(msg, wpSecKey, nil); err == nil {
+		t.Fatalf("expected F3 to reject a tampered credential ciphertext")
+	}
+}
+
+func TestConfigF3RequiresAKey(t *testing.T) {
+	config := &Config{}
+	if _, err := config.F3(&MessagePack{}, nil, nil); err == nil {
+		t.Fatalf("expected F3 to require either wpSecKey or vcSecKey")
+	}
+}
+
+func TestConfigF2WithoutF1FirstFails(t *testing.T) {
+	config := &Config{}
+	userMap := map[string]*UserData{"user-1": {Email: "user-1@example.com"}}
+
+	if _, err := config.F2("user-1", []byte("vc"), nil, "https://wp.example.com", userMap); err == nil {
+		t.Fatalf("expected F2 to fail when VcPubKey/WpPubKey are not set")
+	} else if !errors.Is(err, internal.ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
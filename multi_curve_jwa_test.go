@@ -0,0 +1,42 @@
+package cvc
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+)
+
+func TestGenerateSecretKeyOnCurve(t *testing.T) {
+	for _, crv := range []jwa.EllipticCurveAlgorithm{jwa.P256, jwa.P384, jwa.P521} {
+		key, err := GenerateSecretKeyOnCurve(crv)
+		if err != nil {
+			t.Fatalf("GenerateSecretKeyOnCurve(%s) returned an error: %v", crv, err)
+		}
+		if key == nil {
+			t.Fatalf("GenerateSecretKeyOnCurve(%s) returned a nil key", crv)
+		}
+	}
+}
+
+func TestGenerateSecretKeyOnCurveRejectsUnsupportedCurve(t *testing.T) {
+	if _, err := GenerateSecretKeyOnCurve(jwa.Ed25519); err == nil {
+		t.Fatalf("expected an error for an unsupported jwa curve")
+	}
+}
+
+func TestGenerateEd25519Key(t *testing.T) {
+	key, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatalf("GenerateEd25519Key returned an error: %v", err)
+	}
+
+	var privateKey ed25519.PrivateKey
+	if err := key.Raw(&privateKey); err != nil {
+		t.Fatalf("failed to extract Ed25519 private key: %v", err)
+	}
+
+	if len(privateKey) != ed25519.PrivateKeySize {
+		t.Errorf("expected private key of size %d, got %d", ed25519.PrivateKeySize, len(privateKey))
+	}
+}
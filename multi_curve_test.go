@@ -0,0 +1,45 @@
+package cvc
+
+import (
+	"testing"
+
+	"github.com/MyNextID/cvc-go/internal"
+)
+
+func TestGenerateSecretKeyForCurve(t *testing.T) {
+	curves := []internal.Curve{
+		internal.CurveP256,
+		internal.CurveP384,
+		internal.CurveP521,
+		internal.CurveSecp256k1,
+	}
+
+	for _, curve := range curves {
+		curve := curve
+		t.Run(curve.String(), func(t *testing.T) {
+			key, err := GenerateSecretKeyForCurve(curve)
+			if err != nil {
+				t.Fatalf("GenerateSecretKeyForCurve(%s) returned an error: %v", curve, err)
+			}
+			if key == nil {
+				t.Fatalf("GenerateSecretKeyForCurve(%s) returned a nil key", curve)
+			}
+		})
+	}
+}
+
+func TestAddSecretKeysRejectsMismatchedCurves(t *testing.T) {
+	p256Key, err := GenerateSecretKeyForCurve(internal.CurveP256)
+	if err != nil {
+		t.Fatalf("failed to generate P-256 key: %v", err)
+	}
+
+	p384Key, err := GenerateSecretKeyForCurve(internal.CurveP384)
+	if err != nil {
+		t.Fatalf("failed to generate P-384 key: %v", err)
+	}
+
+	if _, err := AddSecretKeys(p256Key, p384Key); err == nil {
+		t.Fatalf("expected AddSecretKeys to reject keys from different curves")
+	}
+}
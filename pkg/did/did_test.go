@@ -0,0 +1,101 @@
+package did
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func generateTestPublicKey(t *testing.T) jwk.Key {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	key, err := jwk.FromRaw(privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to convert test key to JWK: %v", err)
+	}
+
+	return key
+}
+
+func TestNewJWKDocumentRoundTrip(t *testing.T) {
+	key := generateTestPublicKey(t)
+
+	doc, err := NewJWKDocument(key)
+	if err != nil {
+		t.Fatalf("NewJWKDocument returned an error: %v", err)
+	}
+
+	if len(doc.VerificationMethod) != 1 {
+		t.Fatalf("expected exactly one verification method, got %d", len(doc.VerificationMethod))
+	}
+	if doc.VerificationMethod[0].ID != doc.ID+"#0" {
+		t.Fatalf("verification method id = %q, want %q", doc.VerificationMethod[0].ID, doc.ID+"#0")
+	}
+
+	docBytes, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("failed to marshal document: %v", err)
+	}
+
+	keys, err := ParseDocument(docBytes)
+	if err != nil {
+		t.Fatalf("ParseDocument returned an error: %v", err)
+	}
+	if len(keys) != 1 {
+		t.Fatalf("expected exactly one parsed key, got %d", len(keys))
+	}
+
+	var want, got ecdsa.PublicKey
+	if err := key.Raw(&want); err != nil {
+		t.Fatalf("failed to extract original public key: %v", err)
+	}
+	if err := keys[0].Raw(&got); err != nil {
+		t.Fatalf("failed to extract parsed public key: %v", err)
+	}
+	if want.X.Cmp(got.X) != 0 || want.Y.Cmp(got.Y) != 0 {
+		t.Fatalf("parsed public key does not match original")
+	}
+}
+
+func TestNewWebDocumentMultipleKeys(t *testing.T) {
+	keyA := generateTestPublicKey(t)
+	keyB := generateTestPublicKey(t)
+
+	doc, err := NewWebDocument("example.com", keyA, keyB)
+	if err != nil {
+		t.Fatalf("NewWebDocument returned an error: %v", err)
+	}
+
+	if doc.ID != "did:web:example.com" {
+		t.Fatalf("document id = %q, want %q", doc.ID, "did:web:example.com")
+	}
+	if len(doc.VerificationMethod) != 2 {
+		t.Fatalf("expected two verification methods, got %d", len(doc.VerificationMethod))
+	}
+	if doc.VerificationMethod[0].ID == doc.VerificationMethod[1].ID {
+		t.Fatalf("expected distinct fragment ids for distinct keys")
+	}
+}
+
+func TestNewWebDocumentRejectsEmptyDomain(t *testing.T) {
+	key := generateTestPublicKey(t)
+
+	if _, err := NewWebDocument("", key); err == nil {
+		t.Fatalf("expected an error for an empty domain")
+	}
+}
+
+func TestParseDocumentRejectsEmptyVerificationMethod(t *testing.T) {
+	if _, err := ParseDocument([]byte(`{"id":"did:web:example.com"}`)); err == nil {
+		t.Fatalf("expected an error for a document with no verification methods")
+	}
+}
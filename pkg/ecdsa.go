@@ -13,9 +13,12 @@ func PublicECDSAToBytes(pub *ecdsa.PublicKey) []byte {
 
 // PublicBytesToECDSA elliptic.Marshal and elliptic.Unmarshal are deprecated in favor of the crypto/ecdh package, but that's specifically for ECDH operations. For ECDSA, these functions are still the standard way to handle point marshaling/unmarshaling, so we're good to use them.
 func PublicBytesToECDSA(data []byte) (*ecdsa.PublicKey, error) {
-	// Ensure the key is for P-256 (adjust if you need other curves)
-	curve := elliptic.P256()
+	return PublicBytesToECDSAOnCurve(data, elliptic.P256())
+}
 
+// PublicBytesToECDSAOnCurve unmarshals an uncompressed SEC1 point into an
+// ECDSA public key on the given curve.
+func PublicBytesToECDSAOnCurve(data []byte, curve elliptic.Curve) (*ecdsa.PublicKey, error) {
 	// Unmarshal into X and Y coordinates
 	x, y := elliptic.Unmarshal(curve, data)
 	if x == nil || y == nil {
@@ -0,0 +1,41 @@
+package pkg
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"fmt"
+	"strings"
+
+	"github.com/MyNextID/cvc-go/pkg/keyio"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// fingerprintTruncatedBytes is 240 bits - the libtrust / Docker v2 token
+// auth fingerprint truncation - expressed in bytes.
+const fingerprintTruncatedBytes = 30
+
+// KeyFingerprint computes the libtrust-style key fingerprint still used
+// across container ecosystems (Docker's token auth, notary, etc.): the
+// SHA-256 digest of key's DER-encoded SubjectPublicKeyInfo, truncated to
+// 240 bits, base32-encoded, and grouped into colon-separated 4-character
+// chunks, e.g. "PYYO:TEWU:...".
+func KeyFingerprint(key jwk.Key) (string, error) {
+	der, err := keyio.MarshalPublicDER(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to DER-encode public key: %w", err)
+	}
+
+	digest := sha256.Sum256(der)
+	encoded := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(digest[:fingerprintTruncatedBytes])
+
+	groups := make([]string, 0, (len(encoded)+3)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		end := i + 4
+		if end > len(encoded) {
+			end = len(encoded)
+		}
+		groups = append(groups, encoded[i:end])
+	}
+
+	return strings.Join(groups, ":"), nil
+}
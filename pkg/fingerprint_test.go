@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"regexp"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func generateFingerprintTestKey(t *testing.T) jwk.Key {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	key, err := jwk.FromRaw(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to convert test key to JWK: %v", err)
+	}
+
+	return key
+}
+
+var fingerprintFormat = regexp.MustCompile(`^([A-Z2-7]{4}:){11}[A-Z2-7]{4}$`)
+
+func TestKeyFingerprintFormat(t *testing.T) {
+	key := generateFingerprintTestKey(t)
+
+	fingerprint, err := KeyFingerprint(key)
+	if err != nil {
+		t.Fatalf("KeyFingerprint returned an error: %v", err)
+	}
+
+	if !fingerprintFormat.MatchString(fingerprint) {
+		t.Fatalf("fingerprint %q does not match the expected twelve 4-character base32 groups", fingerprint)
+	}
+}
+
+func TestKeyFingerprintIsDeterministic(t *testing.T) {
+	key := generateFingerprintTestKey(t)
+
+	first, err := KeyFingerprint(key)
+	if err != nil {
+		t.Fatalf("KeyFingerprint returned an error: %v", err)
+	}
+	second, err := KeyFingerprint(key)
+	if err != nil {
+		t.Fatalf("KeyFingerprint returned an error: %v", err)
+	}
+
+	if first != second {
+		t.Fatalf("KeyFingerprint is not deterministic: %q != %q", first, second)
+	}
+}
+
+func TestKeyFingerprintDiffersAcrossKeys(t *testing.T) {
+	first, err := KeyFingerprint(generateFingerprintTestKey(t))
+	if err != nil {
+		t.Fatalf("KeyFingerprint returned an error: %v", err)
+	}
+	second, err := KeyFingerprint(generateFingerprintTestKey(t))
+	if err != nil {
+		t.Fatalf("KeyFingerprint returned an error: %v", err)
+	}
+
+	if first == second {
+		t.Fatalf("expected distinct keys to produce distinct fingerprints")
+	}
+}
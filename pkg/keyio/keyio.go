@@ -0,0 +1,165 @@
+// Package keyio round-trips the jwk.Key values produced by this module
+// through the PEM/DER encodings used by existing PKI tooling, so keys from
+// GenerateSecretKey and AddPublicKeys can be exported to - or imported
+// from - certificate authorities, HSMs, and other systems that don't speak
+// JWK.
+package keyio
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+const (
+	pemPrivateKeyType = "PRIVATE KEY"
+	pemPublicKeyType  = "PUBLIC KEY"
+)
+
+// MarshalPrivateDER encodes key's private component as a PKCS#8 DER document.
+func MarshalPrivateDER(key jwk.Key) ([]byte, error) {
+	rawKey, err := rawPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("keyio: failed to marshal PKCS#8 private key: %w", err)
+	}
+
+	return der, nil
+}
+
+// MarshalPublicDER encodes key's public component as a PKIX/SPKI DER document.
+func MarshalPublicDER(key jwk.Key) ([]byte, error) {
+	rawKey, err := rawPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(rawKey)
+	if err != nil {
+		return nil, fmt.Errorf("keyio: failed to marshal PKIX public key: %w", err)
+	}
+
+	return der, nil
+}
+
+// MarshalPrivatePEM encodes key's private component as a PKCS#8 PEM block.
+func MarshalPrivatePEM(key jwk.Key) ([]byte, error) {
+	der, err := MarshalPrivateDER(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKeyType, Bytes: der}), nil
+}
+
+// MarshalPublicPEM encodes key's public component as a PKIX/SPKI PEM block.
+func MarshalPublicPEM(key jwk.Key) ([]byte, error) {
+	der, err := MarshalPublicDER(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKeyType, Bytes: der}), nil
+}
+
+// ParseKey parses data as a JWK key, auto-detecting whether it is PEM,
+// raw PKCS#8/PKIX DER, or JWK JSON, and always returns a jwk.Key so
+// downstream calls such as AddSecretKeys and DeriveSecretKey work
+// uniformly regardless of the input format.
+func ParseKey(data []byte) (jwk.Key, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		return parseDER(block.Bytes)
+	}
+
+	if looksLikeJSON(data) {
+		key, err := jwk.ParseKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("keyio: failed to parse JWK: %w", err)
+		}
+		return key, nil
+	}
+
+	return parseDER(data)
+}
+
+// ParseCertificatePublicKey parses an X.509 certificate (PEM or DER) and
+// returns its public key as a jwk.Key.
+func ParseCertificatePublicKey(data []byte) (jwk.Key, error) {
+	der := data
+	if block, _ := pem.Decode(data); block != nil {
+		der = block.Bytes
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("keyio: failed to parse X.509 certificate: %w", err)
+	}
+
+	key, err := jwk.FromRaw(cert.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("keyio: failed to convert certificate public key to JWK: %w", err)
+	}
+
+	return key, nil
+}
+
+func parseDER(der []byte) (jwk.Key, error) {
+	if privateKey, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		key, err := jwk.FromRaw(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("keyio: failed to convert PKCS#8 private key to JWK: %w", err)
+		}
+		return key, nil
+	}
+
+	if publicKey, err := x509.ParsePKIXPublicKey(der); err == nil {
+		key, err := jwk.FromRaw(publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("keyio: failed to convert PKIX public key to JWK: %w", err)
+		}
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("keyio: data is neither a recognized PEM block, PKCS#8/PKIX DER document, nor JWK JSON")
+}
+
+func looksLikeJSON(data []byte) bool {
+	for _, b := range data {
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '{':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+func rawPrivateKey(key jwk.Key) (interface{}, error) {
+	var raw interface{}
+	if err := key.Raw(&raw); err != nil {
+		return nil, fmt.Errorf("keyio: failed to extract raw private key: %w", err)
+	}
+	return raw, nil
+}
+
+func rawPublicKey(key jwk.Key) (interface{}, error) {
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("keyio: failed to derive public key: %w", err)
+	}
+
+	var raw interface{}
+	if err := publicKey.Raw(&raw); err != nil {
+		return nil, fmt.Errorf("keyio: failed to extract raw public key: %w", err)
+	}
+	return raw, nil
+}
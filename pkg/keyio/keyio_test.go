@@ -0,0 +1,101 @@
+package keyio
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func generateTestKey(t *testing.T) jwk.Key {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	key, err := jwk.FromRaw(privateKey)
+	if err != nil {
+		t.Fatalf("failed to convert test key to JWK: %v", err)
+	}
+
+	return key
+}
+
+func TestPrivatePEMRoundTrip(t *testing.T) {
+	key := generateTestKey(t)
+
+	pemBytes, err := MarshalPrivatePEM(key)
+	if err != nil {
+		t.Fatalf("MarshalPrivatePEM returned an error: %v", err)
+	}
+
+	parsed, err := ParseKey(pemBytes)
+	if err != nil {
+		t.Fatalf("ParseKey returned an error: %v", err)
+	}
+
+	var original, roundTripped ecdsa.PrivateKey
+	if err := key.Raw(&original); err != nil {
+		t.Fatalf("failed to extract original key: %v", err)
+	}
+	if err := parsed.Raw(&roundTripped); err != nil {
+		t.Fatalf("failed to extract round-tripped key: %v", err)
+	}
+
+	if original.D.Cmp(roundTripped.D) != 0 {
+		t.Errorf("round-tripped private key does not match original")
+	}
+}
+
+func TestPublicDERRoundTrip(t *testing.T) {
+	key := generateTestKey(t)
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+
+	derBytes, err := MarshalPublicDER(key)
+	if err != nil {
+		t.Fatalf("MarshalPublicDER returned an error: %v", err)
+	}
+
+	parsed, err := ParseKey(derBytes)
+	if err != nil {
+		t.Fatalf("ParseKey returned an error: %v", err)
+	}
+
+	var original, roundTripped ecdsa.PublicKey
+	if err := publicKey.Raw(&original); err != nil {
+		t.Fatalf("failed to extract original public key: %v", err)
+	}
+	if err := parsed.Raw(&roundTripped); err != nil {
+		t.Fatalf("failed to extract round-tripped public key: %v", err)
+	}
+
+	if original.X.Cmp(roundTripped.X) != 0 || original.Y.Cmp(roundTripped.Y) != 0 {
+		t.Errorf("round-tripped public key does not match original")
+	}
+}
+
+func TestParseKeyAcceptsJWKJSON(t *testing.T) {
+	key := generateTestKey(t)
+
+	jwkBytes, err := json.Marshal(key)
+	if err != nil {
+		t.Fatalf("failed to marshal JWK: %v", err)
+	}
+
+	parsed, err := ParseKey(jwkBytes)
+	if err != nil {
+		t.Fatalf("ParseKey returned an error for JWK JSON input: %v", err)
+	}
+
+	if parsed == nil {
+		t.Fatalf("expected a non-nil parsed key")
+	}
+}
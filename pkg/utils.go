@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"crypto/ecdh"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/sha256"
 	"encoding/json"
@@ -72,7 +73,13 @@ func ValidatePublicKey(curve elliptic.Curve, xBig, yBig *big.Int) error {
 	case elliptic.P521():
 		ecdhCurve = ecdh.P521()
 	default:
-		return fmt.Errorf("unsupported curve for ecdh validation")
+		// Curves crypto/ecdh doesn't know about (e.g. secp256k1) fall back
+		// to the generic on-curve check; it's weaker than ecdh's validation
+		// but is the best Go's stdlib offers for them.
+		if !curve.IsOnCurve(xBig, yBig) {
+			return fmt.Errorf("invalid public key point: not on curve")
+		}
+		return nil
 	}
 
 	// Marshal the point to uncompressed format
@@ -86,3 +93,16 @@ func ValidatePublicKey(curve elliptic.Curve, xBig, yBig *big.Int) error {
 
 	return nil
 }
+
+// ValidateEd25519PublicKey validates an Ed25519 public key's length.
+//
+// Ed25519 public keys are not Weierstrass curve points, so
+// ValidatePublicKey's check does not apply to them. This only guards
+// against a caller passing a key of the wrong size; it does not check
+// that the 32 bytes decode to a point on the Edwards curve.
+func ValidateEd25519PublicKey(pub ed25519.PublicKey) error {
+	if len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("invalid Ed25519 public key: expected %d bytes, got %d", ed25519.PublicKeySize, len(pub))
+	}
+	return nil
+}
@@ -3,7 +3,11 @@ package cvc
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 
+	"github.com/MyNextID/cvc-go/ecies"
+	"github.com/MyNextID/cvc-go/internal"
 	"github.com/MyNextID/cvc-go/pkg"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 )
@@ -11,16 +15,85 @@ import (
 type ProviderConfig struct {
 	MasterSecretKey jwk.Key
 	Dst             string
+	// Curve is the curve new master keys are generated on. The zero value
+	// is internal.CurveP256, matching this module's historical default.
+	Curve internal.Curve
+	// ControllerDID is the did:key or did:web identifier this provider
+	// publishes derived keys under, e.g. via GenerateDIDDocument.
+	ControllerDID string
+	// EncryptTo, if set, seals the GeneratePublicKeys result to this
+	// recipient with ECIES before it leaves the provider, so the derived
+	// key map is never transmitted in the clear.
+	EncryptTo jwk.Key
+	// Signer, if set, derives child keys instead of MasterSecretKey - e.g.
+	// an HSM- or KMS-backed MasterKeySigner, so the master scalar never has
+	// to enter process memory. When nil, MasterSecretKey is wrapped in an
+	// InMemorySigner, preserving this module's original behavior.
+	Signer MasterKeySigner
 }
 
-func (c *ProviderConfig) GeneratePublicKeys(requestJson []byte) ([]byte, error) {
-	// unmarshal request
+// signer returns c.Signer, falling back to an InMemorySigner over
+// MasterSecretKey when no signer has been configured.
+func (c *ProviderConfig) signer() MasterKeySigner {
+	if c.Signer != nil {
+		return c.Signer
+	}
+	return &InMemorySigner{MasterKey: c.MasterSecretKey}
+}
+
+// parseGeneratePublicKeysRequest unmarshals requestJson into the hashes to
+// derive keys for and the Algorithm the issuer negotiated. It accepts both
+// the current wire format - a generatePublicKeysRequest object - and a
+// bare JSON array of hashes, the format this module used before algorithm
+// negotiation existed. negotiated is true whenever requestJson is a
+// request object, even one without an algorithm field (alg is then the
+// zero value, AlgorithmP256), and false for the legacy bare-array format,
+// so a caller can skip validating alg for an old request that never
+// expressed an opinion on curve.
+func parseGeneratePublicKeysRequest(requestJson []byte) (hashes []string, alg Algorithm, negotiated bool, err error) {
+	var req generatePublicKeysRequest
+	if err := json.Unmarshal(requestJson, &req); err == nil {
+		return req.Hashes, req.Algorithm, true, nil
+	}
+
 	var hashSlices []string
-	err := json.Unmarshal(requestJson, &hashSlices)
+	if err := json.Unmarshal(requestJson, &hashSlices); err != nil {
+		return nil, 0, false, fmt.Errorf("failed to unmarshal request %s", err)
+	}
+
+	return hashSlices, AlgorithmP256, false, nil
+}
+
+// checkAlgorithm rejects a request whose negotiated Algorithm doesn't
+// match the curve this provider's master key actually derives children
+// on, so a curve mismatch surfaces here - as a clear error - rather than
+// downstream when the issuer tries to combine an incompatible key in
+// AddPublicKeys.
+func (c *ProviderConfig) checkAlgorithm(alg Algorithm) error {
+	curve, ok := curveForAlgorithm(alg)
+	if !ok {
+		return fmt.Errorf("wallet provider: algorithm %s is not supported by this provider", alg)
+	}
+	if curve != c.Curve {
+		return fmt.Errorf("wallet provider: requested algorithm %s does not match this provider's %s configuration", alg, c.Curve)
+	}
+	return nil
+}
+
+func (c *ProviderConfig) GeneratePublicKeys(requestJson []byte) ([]byte, error) {
+	hashSlices, alg, negotiated, err := parseGeneratePublicKeysRequest(requestJson)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal request %s", err)
+		return nil, err
 	}
 
+	if negotiated {
+		if err := c.checkAlgorithm(alg); err != nil {
+			return nil, err
+		}
+	}
+
+	signer := c.signer()
+
 	// prepare return item
 	keyMap := make(map[string]KeyData)
 
@@ -36,7 +109,7 @@ func (c *ProviderConfig) GeneratePublicKeys(requestJson []byte) ([]byte, error)
 		dstByte := []byte(c.Dst)
 
 		// derive public key
-		derivedSecretKey, err := DeriveSecretKey(c.MasterSecretKey, context, dstByte)
+		derivedSecretKey, err := signer.DeriveChild(context, dstByte)
 		if err != nil {
 			return nil, fmt.Errorf("failed to derive secret key %s", err)
 		}
@@ -61,5 +134,177 @@ func (c *ProviderConfig) GeneratePublicKeys(requestJson []byte) ([]byte, error)
 	if err != nil {
 		return nil, err
 	}
-	return keyMapBytes, nil
+
+	if c.EncryptTo == nil {
+		return keyMapBytes, nil
+	}
+
+	payload, err := ecies.Encrypt(c.EncryptTo, keyMapBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal key map to recipient: %w", err)
+	}
+
+	sealedBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sealed key map: %w", err)
+	}
+
+	return sealedBytes, nil
+}
+
+// GeneratePublicKeysBatch is equivalent to GeneratePublicKeys but, when the
+// signer implements BatchMasterKeySigner (InMemorySigner does, through
+// DeriveSecretKeysBatch), derives all requested keys with a single
+// DeriveChildren call, so a large request only pays the derivation setup
+// cost once instead of once per hash. Other signers get one DeriveChild
+// call per hash.
+func (c *ProviderConfig) GeneratePublicKeysBatch(requestJson []byte) ([]byte, error) {
+	hashSlices, alg, negotiated, err := parseGeneratePublicKeysRequest(requestJson)
+	if err != nil {
+		return nil, err
+	}
+
+	if negotiated {
+		if err := c.checkAlgorithm(alg); err != nil {
+			return nil, err
+		}
+	}
+
+	keyIDs := make([]string, len(hashSlices))
+	contexts := make([][]byte, len(hashSlices))
+	for i, hash := range hashSlices {
+		keyID := pkg.GenerateUUID()
+		keyIDs[i] = keyID
+		contexts[i] = append([]byte(keyID), hash...)
+	}
+
+	dstBytes := []byte(c.Dst)
+	signer := c.signer()
+
+	var derivedSecretKeys []jwk.Key
+	var derivationErrs []error
+
+	if batchSigner, ok := signer.(BatchMasterKeySigner); ok {
+		derivedSecretKeys, derivationErrs, err = batchSigner.DeriveChildren(contexts, dstBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive secret keys %s", err)
+		}
+	} else {
+		derivedSecretKeys = make([]jwk.Key, len(contexts))
+		derivationErrs = make([]error, len(contexts))
+		for i, context := range contexts {
+			derivedSecretKeys[i], derivationErrs[i] = signer.DeriveChild(context, dstBytes)
+		}
+	}
+
+	keyMap := make(map[string]KeyData)
+	for i, hash := range hashSlices {
+		if derivationErrs[i] != nil {
+			return nil, fmt.Errorf("failed to derive secret key for hash %q: %w", hash, derivationErrs[i])
+		}
+
+		derivedPublicKey, err := derivedSecretKeys[i].PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get public key %s", err)
+		}
+
+		pubKeyBytes, err := pkg.KeyJWKToJson(derivedPublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal jwk to json bytes %w", err)
+		}
+
+		keyMap[hash] = KeyData{KeyID: keyIDs[i], WpPubkey: pubKeyBytes}
+	}
+
+	keyMapBytes, err := json.Marshal(keyMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.EncryptTo == nil {
+		return keyMapBytes, nil
+	}
+
+	payload, err := ecies.Encrypt(c.EncryptTo, keyMapBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal key map to recipient: %w", err)
+	}
+
+	sealedBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal sealed key map: %w", err)
+	}
+
+	return sealedBytes, nil
+}
+
+// generatePublicKeysStreamEntry is one line of GeneratePublicKeysStream's
+// NDJSON output: the hash the caller requested a key for, plus the key
+// itself.
+type generatePublicKeysStreamEntry struct {
+	Hash string `json:"hash"`
+	KeyData
+}
+
+// GeneratePublicKeysStream is GeneratePublicKeys for large requests: rather
+// than deriving every key before returning anything, it writes one NDJSON
+// line per derived key to w as soon as that key is ready, so a caller
+// streaming the HTTP response body - or relaying it as Server-Sent Events -
+// can start forwarding results before the whole batch finishes. If w also
+// implements http.Flusher (true of an http.ResponseWriter), each line is
+// flushed immediately. There is no single payload left to ECIES-seal once
+// keys are written incrementally, so GeneratePublicKeysStream refuses to
+// run against a ProviderConfig with EncryptTo set rather than silently
+// streaming key material in the clear; use GeneratePublicKeys or
+// GeneratePublicKeysBatch instead when EncryptTo is required.
+func (c *ProviderConfig) GeneratePublicKeysStream(w io.Writer, requestJson []byte) error {
+	if c.EncryptTo != nil {
+		return fmt.Errorf("wallet provider: GeneratePublicKeysStream does not support EncryptTo; use GeneratePublicKeys or GeneratePublicKeysBatch instead")
+	}
+
+	hashSlices, alg, negotiated, err := parseGeneratePublicKeysRequest(requestJson)
+	if err != nil {
+		return err
+	}
+
+	if negotiated {
+		if err := c.checkAlgorithm(alg); err != nil {
+			return err
+		}
+	}
+
+	signer := c.signer()
+	dstByte := []byte(c.Dst)
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+
+	for _, hash := range hashSlices {
+		keyID := pkg.GenerateUUID()
+		context := append([]byte(keyID), hash...)
+
+		derivedSecretKey, err := signer.DeriveChild(context, dstByte)
+		if err != nil {
+			return fmt.Errorf("failed to derive secret key %s", err)
+		}
+
+		derivedPublicKey, err := derivedSecretKey.PublicKey()
+		if err != nil {
+			return fmt.Errorf("failed to get public key %s", err)
+		}
+
+		pubKeyBytes, err := pkg.KeyJWKToJson(derivedPublicKey)
+		if err != nil {
+			return fmt.Errorf("failed to marshal jwk to json bytes %w", err)
+		}
+
+		entry := generatePublicKeysStreamEntry{Hash: hash, KeyData: KeyData{KeyID: keyID, WpPubkey: pubKeyBytes}}
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("failed to write streamed key entry: %w", err)
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+
+	return nil
 }
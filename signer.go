@@ -0,0 +1,66 @@
+package cvc
+
+import (
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// MasterKeySigner abstracts where a provider's master secret key lives and
+// how child keys are derived from it. Implementations range from an
+// in-process jwk.Key (InMemorySigner, this module's original behavior) to
+// an HSM or a remote KMS, where the master scalar should never enter Go
+// process memory.
+type MasterKeySigner interface {
+	// DeriveChild derives the child key for context and dst without
+	// exposing the master scalar to the caller. InMemorySigner returns the
+	// child secret key; KMSSigner and PKCS11Signer return only the child's
+	// public key.
+	DeriveChild(context, dst []byte) (jwk.Key, error)
+	// PublicKey returns the master key's public component.
+	PublicKey() (jwk.Key, error)
+}
+
+// BatchMasterKeySigner is an optional extension of MasterKeySigner for
+// backends that can derive many child keys more efficiently than one
+// DeriveChild call per context (e.g. a single cgo crossing, or a single
+// HSM round trip). GeneratePublicKeysBatch uses this when a signer
+// implements it and falls back to repeated DeriveChild calls otherwise.
+type BatchMasterKeySigner interface {
+	MasterKeySigner
+	DeriveChildren(contexts [][]byte, dst []byte) ([]jwk.Key, []error, error)
+}
+
+// InMemorySigner is this module's original behavior: the master key lives
+// in-process as a jwk.Key and child keys are derived directly in Go/cgo.
+type InMemorySigner struct {
+	MasterKey jwk.Key
+}
+
+func (s *InMemorySigner) DeriveChild(context, dst []byte) (jwk.Key, error) {
+	return DeriveSecretKey(s.MasterKey, context, dst)
+}
+
+func (s *InMemorySigner) DeriveChildren(contexts [][]byte, dst []byte) ([]jwk.Key, []error, error) {
+	return DeriveSecretKeysBatch(s.MasterKey, contexts, dst)
+}
+
+func (s *InMemorySigner) PublicKey() (jwk.Key, error) {
+	return s.MasterKey.PublicKey()
+}
+
+var _ BatchMasterKeySigner = (*InMemorySigner)(nil)
+
+// deriveTweak maps context into a scalar with the pure-Go hash-to-field
+// expander (internal.GenerateSecretKeyDeterministic), without needing the
+// master key at all. DeriveSecretKey uses that expander for every curve
+// but P-256, which it routes through the C library instead.
+// HSM- and KMS-backed signers combine this tweak with the master scalar
+// using the backend's native EC-add-scalar operation, so the master scalar
+// never has to leave the backend to compute a child key.
+func deriveTweak(context, dst []byte, curve internal.Curve) ([]byte, error) {
+	tweakMaterial, err := internal.GenerateSecretKeyDeterministic(context, dst, curve)
+	if err != nil {
+		return nil, internal.WrapError(err, "failed to derive tweak from context")
+	}
+	return tweakMaterial.PrivateKeyBytes, nil
+}
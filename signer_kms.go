@@ -0,0 +1,53 @@
+package cvc
+
+import (
+	"fmt"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// KMSClient is the transport a KMSSigner uses to reach a remote key
+// management service. Implementations typically wrap a gRPC or HTTP client
+// for a specific KMS; KMSSigner itself is transport-agnostic.
+type KMSClient interface {
+	// AddScalar asks the KMS to compute (masterKey + tweak) mod n on the
+	// key identified by masterKeyID and return the resulting child public
+	// key as a JWK key.
+	AddScalar(masterKeyID string, tweak []byte) (jwk.Key, error)
+	// MasterPublicKey returns the public component of masterKeyID.
+	MasterPublicKey(masterKeyID string) (jwk.Key, error)
+}
+
+// KMSSigner derives child keys via a remote KMSClient, so the master scalar
+// never leaves the KMS's boundary. The context/dst tweak is always computed
+// in software (see deriveTweak) and only the tweak crosses the wire.
+type KMSSigner struct {
+	Client      KMSClient
+	MasterKeyID string
+	Curve       internal.Curve
+}
+
+func (s *KMSSigner) DeriveChild(context, dst []byte) (jwk.Key, error) {
+	tweak, err := deriveTweak(context, dst, s.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	childKey, err := s.Client.AddScalar(s.MasterKeyID, tweak)
+	if err != nil {
+		return nil, fmt.Errorf("kms: failed to derive child key: %w", err)
+	}
+
+	return childKey, nil
+}
+
+func (s *KMSSigner) PublicKey() (jwk.Key, error) {
+	pubKey, err := s.Client.MasterPublicKey(s.MasterKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("kms: failed to fetch master public key: %w", err)
+	}
+	return pubKey, nil
+}
+
+var _ MasterKeySigner = (*KMSSigner)(nil)
@@ -0,0 +1,162 @@
+package cvc
+
+import (
+	"fmt"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/MyNextID/cvc-go/pkg"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/miekg/pkcs11"
+)
+
+// PKCS11Signer derives child keys from a master key that lives on a
+// PKCS#11 token (an HSM or a software token such as SoftHSM) and never
+// extracts the master scalar into Go memory.
+//
+// Because hash-to-field cannot run inside most tokens, DeriveChild uses a
+// hybrid strategy: the context/dst tweak is derived in software by
+// deriveTweak, then the token computes
+// d_child = (d_master + tweak) mod n via ScalarAddMechanism, a
+// vendor-specific EC-scalar-add mechanism the caller configures (PKCS#11
+// v2.40 does not standardize one).
+type PKCS11Signer struct {
+	Module   string
+	Slot     uint
+	PIN      string
+	KeyLabel string
+	Curve    internal.Curve
+
+	// ScalarAddMechanism is the vendor-specific CKM_* mechanism this token
+	// uses to add a software-supplied scalar to the private key object
+	// identified by KeyLabel, producing a new key object without ever
+	// returning the private scalar.
+	ScalarAddMechanism uint
+
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+}
+
+// Open initializes the PKCS#11 module and logs into the token. It must be
+// called before DeriveChild or PublicKey.
+func (s *PKCS11Signer) Open() error {
+	s.ctx = pkcs11.New(s.Module)
+	if s.ctx == nil {
+		return fmt.Errorf("pkcs11: failed to load module %q", s.Module)
+	}
+	if err := s.ctx.Initialize(); err != nil {
+		return fmt.Errorf("pkcs11: failed to initialize module: %w", err)
+	}
+
+	session, err := s.ctx.OpenSession(s.Slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		return fmt.Errorf("pkcs11: failed to open session: %w", err)
+	}
+	s.session = session
+
+	if err := s.ctx.Login(s.session, pkcs11.CKU_USER, s.PIN); err != nil {
+		return fmt.Errorf("pkcs11: failed to login: %w", err)
+	}
+
+	return nil
+}
+
+// Close logs out and releases the PKCS#11 session.
+func (s *PKCS11Signer) Close() error {
+	if s.ctx == nil {
+		return nil
+	}
+	s.ctx.Logout(s.session)
+	s.ctx.CloseSession(s.session)
+	s.ctx.Finalize()
+	s.ctx.Destroy()
+	return nil
+}
+
+// DeriveChild derives a child key by tweaking the token-resident master key
+// without ever reading its private scalar, and returns the child's public
+// key read off the new token object.
+func (s *PKCS11Signer) DeriveChild(context, dst []byte) (jwk.Key, error) {
+	tweak, err := deriveTweak(context, dst, s.Curve)
+	if err != nil {
+		return nil, err
+	}
+
+	privateHandle, err := s.findPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(s.ScalarAddMechanism, tweak)}
+	childTemplate := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, false),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+	}
+
+	childHandle, err := s.ctx.DeriveKey(s.session, mechanism, privateHandle, childTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: scalar-add derive failed: %w", err)
+	}
+
+	return s.publicKeyFromHandle(childHandle)
+}
+
+// PublicKey returns the master key's public component.
+func (s *PKCS11Signer) PublicKey() (jwk.Key, error) {
+	privateHandle, err := s.findPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return s.publicKeyFromHandle(privateHandle)
+}
+
+func (s *PKCS11Signer) findPrivateKey() (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, s.KeyLabel),
+	}
+	if err := s.ctx.FindObjectsInit(s.session, template); err != nil {
+		return 0, fmt.Errorf("pkcs11: find objects init failed: %w", err)
+	}
+	defer s.ctx.FindObjectsFinal(s.session)
+
+	handles, _, err := s.ctx.FindObjects(s.session, 1)
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: find objects failed: %w", err)
+	}
+	if len(handles) == 0 {
+		return 0, fmt.Errorf("pkcs11: no private key with label %q", s.KeyLabel)
+	}
+	return handles[0], nil
+}
+
+// publicKeyFromHandle reads CKA_EC_POINT off the object identified by
+// handle and converts it into a JWK public key.
+func (s *PKCS11Signer) publicKeyFromHandle(handle pkcs11.ObjectHandle) (jwk.Key, error) {
+	attrs, err := s.ctx.GetAttributeValue(s.session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: failed to read EC point: %w", err)
+	}
+
+	// CKA_EC_POINT is a DER-encoded OCTET STRING wrapping the uncompressed
+	// SEC1 point; strip the two-byte DER header most tokens prepend.
+	ecPoint := attrs[0].Value
+	if len(ecPoint) > 2 && ecPoint[0] == 0x04 {
+		ecPoint = ecPoint[2:]
+	}
+
+	ellipticCurve, err := s.Curve.EllipticCurve()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := pkg.PublicBytesToECDSAOnCurve(ecPoint, ellipticCurve)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrResultConversion, "failed to parse token public key point")
+	}
+
+	return jwk.FromRaw(pubKey)
+}
+
+var _ MasterKeySigner = (*PKCS11Signer)(nil)
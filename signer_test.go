@@ -0,0 +1,191 @@
+package cvc
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"testing"
+)
+
+func TestInMemorySignerMatchesDirectDerivation(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	context := []byte("signer-test-context")
+	dst := []byte("CVC-TEST-DST-v1.0")
+
+	signer := &InMemorySigner{MasterKey: masterKey}
+
+	viaSigner, err := signer.DeriveChild(context, dst)
+	if err != nil {
+		t.Fatalf("DeriveChild returned an error: %v", err)
+	}
+
+	viaDirect, err := DeriveSecretKey(masterKey, context, dst)
+	if err != nil {
+		t.Fatalf("DeriveSecretKey returned an error: %v", err)
+	}
+
+	var fromSigner, fromDirect ecdsa.PrivateKey
+	if err := viaSigner.Raw(&fromSigner); err != nil {
+		t.Fatalf("failed to extract key derived via signer: %v", err)
+	}
+	if err := viaDirect.Raw(&fromDirect); err != nil {
+		t.Fatalf("failed to extract key derived directly: %v", err)
+	}
+
+	if fromSigner.D.Cmp(fromDirect.D) != 0 {
+		t.Errorf("InMemorySigner.DeriveChild diverged from DeriveSecretKey")
+	}
+}
+
+func TestProviderConfigGeneratePublicKeysWithSigner(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	provider := &ProviderConfig{
+		Dst:    "cvc-provider-v1",
+		Signer: &InMemorySigner{MasterKey: masterKey},
+	}
+
+	requestJSON, err := json.Marshal([]string{"hash-one", "hash-two"})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	responseBytes, err := provider.GeneratePublicKeys(requestJSON)
+	if err != nil {
+		t.Fatalf("GeneratePublicKeys returned an error: %v", err)
+	}
+
+	var keyMap map[string]KeyData
+	if err := json.Unmarshal(responseBytes, &keyMap); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(keyMap) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(keyMap))
+	}
+}
+
+func TestProviderConfigGeneratePublicKeysBatchWithSigner(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	provider := &ProviderConfig{
+		Dst:    "cvc-provider-v1",
+		Signer: &InMemorySigner{MasterKey: masterKey},
+	}
+
+	requestJSON, err := json.Marshal([]string{"hash-one", "hash-two", "hash-three"})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	responseBytes, err := provider.GeneratePublicKeysBatch(requestJSON)
+	if err != nil {
+		t.Fatalf("GeneratePublicKeysBatch returned an error: %v", err)
+	}
+
+	var keyMap map[string]KeyData
+	if err := json.Unmarshal(responseBytes, &keyMap); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(keyMap) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(keyMap))
+	}
+}
+
+func TestProviderConfigGeneratePublicKeysStreamWritesOneLinePerHash(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	provider := &ProviderConfig{
+		Dst:    "cvc-provider-v1",
+		Signer: &InMemorySigner{MasterKey: masterKey},
+	}
+
+	requestJSON, err := json.Marshal([]string{"hash-one", "hash-two", "hash-three"})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := provider.GeneratePublicKeysStream(&buf, requestJSON); err != nil {
+		t.Fatalf("GeneratePublicKeysStream returned an error: %v", err)
+	}
+
+	decoder := json.NewDecoder(&buf)
+	seenHashes := make(map[string]bool)
+	var lineCount int
+	for decoder.More() {
+		var entry struct {
+			Hash     string `json:"hash"`
+			KeyID    string `json:"key_id"`
+			WpPubkey []byte `json:"wp_pubkey"`
+		}
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatalf("failed to decode NDJSON line %d: %v", lineCount, err)
+		}
+		if entry.KeyID == "" {
+			t.Fatalf("expected a non-empty key_id on line %d", lineCount)
+		}
+		if len(entry.WpPubkey) == 0 {
+			t.Fatalf("expected a non-empty wp_pubkey on line %d", lineCount)
+		}
+		seenHashes[entry.Hash] = true
+		lineCount++
+	}
+
+	if lineCount != 3 {
+		t.Fatalf("expected 3 NDJSON lines, got %d", lineCount)
+	}
+	for _, hash := range []string{"hash-one", "hash-two", "hash-three"} {
+		if !seenHashes[hash] {
+			t.Fatalf("expected a streamed entry for %q", hash)
+		}
+	}
+}
+
+func TestProviderConfigGeneratePublicKeysStreamRejectsEncryptTo(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+	recipientKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate recipient key: %v", err)
+	}
+	recipientPub, err := recipientKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive recipient public key: %v", err)
+	}
+
+	provider := &ProviderConfig{
+		Dst:       "cvc-provider-v1",
+		Signer:    &InMemorySigner{MasterKey: masterKey},
+		EncryptTo: recipientPub,
+	}
+
+	requestJSON, err := json.Marshal([]string{"hash-one"})
+	if err != nil {
+		t.Fatalf("failed to marshal request: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := provider.GeneratePublicKeysStream(&buf, requestJSON); err == nil {
+		t.Fatalf("expected GeneratePublicKeysStream to reject a ProviderConfig with EncryptTo set")
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output to be written once EncryptTo is rejected, got %q", buf.String())
+	}
+}
@@ -0,0 +1,103 @@
+package cvc
+
+import (
+	"crypto"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/MyNextID/cvc-go/pkg"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// Thumbprint computes key's RFC 7638 JSON Web Key thumbprint under hash.
+func Thumbprint(key jwk.Key, hash crypto.Hash) ([]byte, error) {
+	if key == nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, "key cannot be nil")
+	}
+
+	thumbprint, err := key.Thumbprint(hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute JWK thumbprint: %w", err)
+	}
+
+	return thumbprint, nil
+}
+
+// ThumbprintURI computes key's RFC 7638 thumbprint under SHA-256 and
+// returns it base64url-encoded, the form used for `kid` values and DID
+// verification method fragments throughout this module.
+func ThumbprintURI(key jwk.Key) (string, error) {
+	thumbprint, err := Thumbprint(key, crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// LibtrustFingerprint computes the libtrust-style key fingerprint Docker's
+// token auth uses. It's a thin wrapper over pkg.KeyFingerprint, the single
+// implementation of the real libtrust/Docker algorithm this module carries;
+// it exists here for cvc-go callers that want the fingerprint alongside
+// Thumbprint/ThumbprintURI without importing pkg directly.
+func LibtrustFingerprint(key jwk.Key) (string, error) {
+	if key == nil {
+		return "", internal.WrapError(internal.ErrInvalidKey, "key cannot be nil")
+	}
+
+	return pkg.KeyFingerprint(key)
+}
+
+// KeyOption configures optional post-processing applied by key-producing
+// functions such as GenerateSecretKey, DeriveSecretKey, and AddPublicKeys.
+type KeyOption func(*keyOptions)
+
+type keyOptions struct {
+	setKid         bool
+	setLibtrustKid bool
+}
+
+// WithKid populates the returned JWK's `kid` field with its RFC 7638 JWK
+// thumbprint, giving fresh keys minted by AddPublicKeys/DeriveSecretKey a
+// stable, content-addressed identifier.
+func WithKid() KeyOption {
+	return func(o *keyOptions) { o.setKid = true }
+}
+
+// WithLibtrustKid populates kid with the libtrust-style truncated-SHA256 /
+// base32 fingerprint ("XXXX:XXXX:...") instead of the raw RFC 7638
+// thumbprint, for interop with Docker-style token auth.
+func WithLibtrustKid() KeyOption {
+	return func(o *keyOptions) { o.setLibtrustKid = true }
+}
+
+// applyKeyOptions runs opts against key, returning key unchanged if no
+// option requested a kid.
+func applyKeyOptions(key jwk.Key, opts []KeyOption) (jwk.Key, error) {
+	var o keyOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !o.setKid && !o.setLibtrustKid {
+		return key, nil
+	}
+
+	var kid string
+	var err error
+	if o.setLibtrustKid {
+		kid, err = LibtrustFingerprint(key)
+	} else {
+		kid, err = ThumbprintURI(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute kid: %w", err)
+	}
+
+	if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+		return nil, fmt.Errorf("failed to set kid: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,108 @@
+package cvc
+
+import (
+	"crypto"
+	"strings"
+	"testing"
+)
+
+func TestThumbprintURIIsStableAndContentAddressed(t *testing.T) {
+	key, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+
+	first, err := ThumbprintURI(publicKey)
+	if err != nil {
+		t.Fatalf("ThumbprintURI returned an error: %v", err)
+	}
+	second, err := ThumbprintURI(publicKey)
+	if err != nil {
+		t.Fatalf("ThumbprintURI returned an error: %v", err)
+	}
+	if first != second {
+		t.Fatalf("ThumbprintURI is not stable: %q != %q", first, second)
+	}
+
+	otherKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+	otherPublicKey, err := otherKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+	otherThumbprint, err := ThumbprintURI(otherPublicKey)
+	if err != nil {
+		t.Fatalf("ThumbprintURI returned an error: %v", err)
+	}
+	if first == otherThumbprint {
+		t.Fatalf("expected distinct keys to produce distinct thumbprints")
+	}
+}
+
+func TestThumbprintRejectsNilKey(t *testing.T) {
+	if _, err := Thumbprint(nil, crypto.SHA256); err == nil {
+		t.Fatalf("expected Thumbprint to reject a nil key")
+	}
+}
+
+func TestLibtrustFingerprintFormat(t *testing.T) {
+	key, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+
+	fingerprint, err := LibtrustFingerprint(publicKey)
+	if err != nil {
+		t.Fatalf("LibtrustFingerprint returned an error: %v", err)
+	}
+
+	groups := strings.Split(fingerprint, ":")
+	if len(groups) == 0 {
+		t.Fatalf("expected LibtrustFingerprint to produce colon-separated groups")
+	}
+	for _, group := range groups {
+		if len(group) == 0 || len(group) > 4 {
+			t.Fatalf("expected each group to have 1-4 characters, got %q", group)
+		}
+	}
+}
+
+func TestWithKidPopulatesThumbprint(t *testing.T) {
+	key, err := GenerateSecretKey(WithKid())
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+
+	wantKid, err := ThumbprintURI(key)
+	if err != nil {
+		t.Fatalf("ThumbprintURI returned an error: %v", err)
+	}
+	if key.KeyID() != wantKid {
+		t.Fatalf("kid = %q, want %q", key.KeyID(), wantKid)
+	}
+}
+
+func TestWithLibtrustKidPopulatesFingerprint(t *testing.T) {
+	key, err := GenerateSecretKey(WithLibtrustKid())
+	if err != nil {
+		t.Fatalf("GenerateSecretKey returned an error: %v", err)
+	}
+
+	wantKid, err := LibtrustFingerprint(key)
+	if err != nil {
+		t.Fatalf("LibtrustFingerprint returned an error: %v", err)
+	}
+	if key.KeyID() != wantKid {
+		t.Fatalf("kid = %q, want %q", key.KeyID(), wantKid)
+	}
+}
@@ -15,6 +15,10 @@ type UserData struct {
 	WpPubKey jwk.Key
 	VcSecKey jwk.Key
 	VcPubKey jwk.Key
+	// ConfirmationKeyID is the libtrust-style fingerprint (pkg.KeyFingerprint)
+	// of the VC+WP confirmation key AddCnfToPayload adds to the VC payload's
+	// `cnf` claim, giving downstream verifiers a deterministic ID for it.
+	ConfirmationKeyID string
 }
 
 type KeyData struct {
@@ -31,6 +35,7 @@ type MessagePack struct {
 	Salt        []byte `json:"salt" msgpack:"salt"`                                 // needed to generate wp secret key
 	Email       string `json:"email" msgpack:"email"`                               // who gets the VC
 	DisplayMap  []byte `json:"display_map" msgpack:"display_map"`                   // how VC looks in wallet
+	Curve       Curve  `json:"curve" msgpack:"curve"`                               // which group EncVC/EncVCSecKey's keys belong to
 }
 
 type SecretKeyData struct {
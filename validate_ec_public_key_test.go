@@ -0,0 +1,123 @@
+package cvc
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func TestValidateECPublicKeyAcceptsGeneratedPoint(t *testing.T) {
+	curve := elliptic.P256()
+
+	secretKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	pubKey, err := secretKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+	ecdsaPub, err := extractPublicKey(pubKey, "test key")
+	if err != nil {
+		t.Fatalf("failed to extract public key: %v", err)
+	}
+
+	if err := validateECPublicKey(curve, ecdsaPub.X, ecdsaPub.Y); err != nil {
+		t.Fatalf("validateECPublicKey rejected a freshly generated point: %v", err)
+	}
+}
+
+func TestValidateECPublicKeyRejectsIdentityPoint(t *testing.T) {
+	curve := elliptic.P256()
+
+	if err := validateECPublicKey(curve, big.NewInt(0), big.NewInt(0)); err == nil {
+		t.Fatalf("expected validateECPublicKey to reject the point at infinity")
+	}
+}
+
+func TestValidateECPublicKeyRejectsOffCurvePoint(t *testing.T) {
+	curve := elliptic.P256()
+	gx, gy := curve.Params().Gx, curve.Params().Gy
+
+	// Perturb Y so (X, Y) no longer satisfies the curve equation.
+	offCurveY := new(big.Int).Add(gy, big.NewInt(1))
+
+	if err := validateECPublicKey(curve, gx, offCurveY); err == nil {
+		t.Fatalf("expected validateECPublicKey to reject an off-curve point")
+	}
+}
+
+func TestValidateECPublicKeyRejectsXGreaterThanOrEqualToP(t *testing.T) {
+	curve := elliptic.P256()
+	gy := curve.Params().Gy
+
+	// A valid residue, re-encoded non-canonically as X+P, must be
+	// rejected even though X mod P would be on the curve.
+	nonCanonicalX := new(big.Int).Add(curve.Params().Gx, curve.Params().P)
+
+	if err := validateECPublicKey(curve, nonCanonicalX, gy); err == nil {
+		t.Fatalf("expected validateECPublicKey to reject X >= p")
+	}
+}
+
+func TestValidateECPublicKeyRejectsYGreaterThanOrEqualToP(t *testing.T) {
+	curve := elliptic.P256()
+	gx := curve.Params().Gx
+
+	nonCanonicalY := new(big.Int).Add(curve.Params().P, big.NewInt(1))
+
+	if err := validateECPublicKey(curve, gx, nonCanonicalY); err == nil {
+		t.Fatalf("expected validateECPublicKey to reject Y >= p")
+	}
+}
+
+func TestValidateECPublicKeyRejectsNilCoordinates(t *testing.T) {
+	curve := elliptic.P256()
+
+	if err := validateECPublicKey(curve, nil, big.NewInt(1)); err == nil {
+		t.Fatalf("expected validateECPublicKey to reject a nil X coordinate")
+	}
+	if err := validateECPublicKey(curve, big.NewInt(1), nil); err == nil {
+		t.Fatalf("expected validateECPublicKey to reject a nil Y coordinate")
+	}
+}
+
+func TestF1RejectsInvalidWalletProviderPublicKey(t *testing.T) {
+	masterKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+	config := &Config{MasterKeyStore: staticMasterKeyStore{key: masterKey}}
+
+	secretKey, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	pubKey, err := secretKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key: %v", err)
+	}
+	ecdsaPub, err := extractPublicKey(pubKey, "test key")
+	if err != nil {
+		t.Fatalf("failed to extract public key: %v", err)
+	}
+
+	// Simulate a malicious wallet provider response by perturbing X so the
+	// point no longer lies on the curve.
+	tampered := *ecdsaPub
+	tampered.X = new(big.Int).Add(ecdsaPub.X, big.NewInt(1))
+	tamperedPubKey, err := jwk.FromRaw(&tampered)
+	if err != nil {
+		t.Fatalf("failed to build tampered JWK: %v", err)
+	}
+
+	userMap := map[string]*UserData{
+		"user-1": {Email: "user-1@example.com", WpPubKey: tamperedPubKey},
+	}
+
+	if err := config.F1("user-1", map[string]interface{}{}, userMap); err == nil {
+		t.Fatalf("expected F1 to reject an invalid wallet provider public key")
+	}
+}
@@ -0,0 +1,155 @@
+package cvc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+// vapidTokenLifetime is how long a VAPIDHeader JWT stays valid. RFC 8292
+// leaves the exact lifetime to the application; 12h mirrors what Web Push
+// implementations commonly use.
+const vapidTokenLifetime = 12 * time.Hour
+
+// GenerateIssuerKeys generates a fresh ES256 (P-256 ECDSA) key pair for use
+// as an issuer identity: the same key can sign IssuerConfig.SigningKey's
+// PrepareMessagePack envelope and authenticate this issuer's wallet
+// provider requests via VAPIDHeader.
+func GenerateIssuerKeys() (jwk.Key, error) {
+	return GenerateSecretKey()
+}
+
+// LoadIssuerKeysFromJWK parses an ES256 private key previously produced by
+// GenerateIssuerKeys (and typically persisted via pkg.JWKToJson) back into
+// a jwk.Key.
+func LoadIssuerKeysFromJWK(jwkJSON []byte) (jwk.Key, error) {
+	key, err := jwk.ParseKey(jwkJSON)
+	if err != nil {
+		return nil, fmt.Errorf("vapid: failed to parse issuer JWK: %w", err)
+	}
+	return key, nil
+}
+
+// VAPIDHeader builds the Authorization header value an IssuerConfig attaches
+// to each wallet-provider request: a short-lived ES256 JWT (aud=audience,
+// sub=issuerID, iat=now, exp=now+12h) alongside the issuer's uncompressed
+// P-256 public key, following the VAPID scheme (RFC 8292) Web Push uses for
+// stateless sender authentication - "vapid t=<jwt>, k=<base64url pubkey>".
+func VAPIDHeader(issuerSigningKey jwk.Key, audience, issuerID string) (string, error) {
+	now := time.Now()
+	token, err := jwt.NewBuilder().
+		Audience([]string{audience}).
+		Subject(issuerID).
+		IssuedAt(now).
+		Expiration(now.Add(vapidTokenLifetime)).
+		Build()
+	if err != nil {
+		return "", fmt.Errorf("vapid: failed to build JWT: %w", err)
+	}
+
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256, issuerSigningKey))
+	if err != nil {
+		return "", fmt.Errorf("vapid: failed to sign JWT: %w", err)
+	}
+
+	pubKeyBytes, err := marshalUncompressedP256PublicKey(issuerSigningKey)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("vapid t=%s, k=%s", signed, base64.RawURLEncoding.EncodeToString(pubKeyBytes)), nil
+}
+
+// VerifyVAPIDHeader parses and verifies the Authorization header VAPIDHeader
+// produces: it recovers the embedded public key, checks it signed the JWT,
+// and checks the JWT's audience matches expectedAud, returning the
+// issuer's public key so the wallet provider can authorize (or log) the
+// specific issuer.
+func VerifyVAPIDHeader(r *http.Request, expectedAud string) (jwk.Key, error) {
+	token, pubKeyB64, err := parseVAPIDAuthorization(r.Header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyBytes, err := base64.RawURLEncoding.DecodeString(pubKeyB64)
+	if err != nil {
+		return nil, fmt.Errorf("vapid: failed to decode public key: %w", err)
+	}
+
+	issuerPubKey, err := unmarshalUncompressedP256PublicKey(pubKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := jwt.Parse([]byte(token), jwt.WithKey(jwa.ES256, issuerPubKey), jwt.WithAudience(expectedAud)); err != nil {
+		return nil, fmt.Errorf("vapid: failed to verify JWT: %w", err)
+	}
+
+	return issuerPubKey, nil
+}
+
+// parseVAPIDAuthorization splits an "vapid t=<jwt>, k=<pubkey>" Authorization
+// header into its t and k parameters.
+func parseVAPIDAuthorization(header string) (token, pubKey string, err error) {
+	const prefix = "vapid "
+	if !strings.HasPrefix(strings.ToLower(header), prefix) {
+		return "", "", fmt.Errorf("vapid: missing or malformed Authorization header")
+	}
+
+	for _, param := range strings.Split(header[len(prefix):], ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch strings.TrimSpace(kv[0]) {
+		case "t":
+			token = strings.TrimSpace(kv[1])
+		case "k":
+			pubKey = strings.TrimSpace(kv[1])
+		}
+	}
+
+	if token == "" || pubKey == "" {
+		return "", "", fmt.Errorf("vapid: Authorization header missing t or k parameter")
+	}
+
+	return token, pubKey, nil
+}
+
+func marshalUncompressedP256PublicKey(key jwk.Key) ([]byte, error) {
+	var pub ecdsa.PublicKey
+	if err := key.Raw(&pub); err != nil {
+		var priv ecdsa.PrivateKey
+		if err := key.Raw(&priv); err != nil {
+			return nil, fmt.Errorf("vapid: key is not an ECDSA P-256 key: %w", err)
+		}
+		pub = priv.PublicKey
+	}
+	if pub.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("vapid: key is not on P-256")
+	}
+
+	return elliptic.Marshal(pub.Curve, pub.X, pub.Y), nil
+}
+
+func unmarshalUncompressedP256PublicKey(data []byte) (jwk.Key, error) {
+	x, y := elliptic.Unmarshal(elliptic.P256(), data)
+	if x == nil {
+		return nil, fmt.Errorf("vapid: public key bytes do not represent a valid P-256 point")
+	}
+
+	key, err := jwk.FromRaw(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+	if err != nil {
+		return nil, fmt.Errorf("vapid: failed to convert public key to JWK: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,110 @@
+package cvc
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVAPIDHeaderRoundTrip(t *testing.T) {
+	issuerKey, err := GenerateIssuerKeys()
+	if err != nil {
+		t.Fatalf("GenerateIssuerKeys returned an error: %v", err)
+	}
+
+	header, err := VAPIDHeader(issuerKey, "https://wallet-provider.example.com", "did:example:issuer")
+	if err != nil {
+		t.Fatalf("VAPIDHeader returned an error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "https://wallet-provider.example.com/generate/pub-key", nil)
+	req.Header.Set("Authorization", header)
+
+	issuerPubKey, err := VerifyVAPIDHeader(req, "https://wallet-provider.example.com")
+	if err != nil {
+		t.Fatalf("VerifyVAPIDHeader returned an error: %v", err)
+	}
+
+	wantPubKey, err := issuerKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive issuer public key: %v", err)
+	}
+	wantBytes, err := marshalUncompressedP256PublicKey(wantPubKey)
+	if err != nil {
+		t.Fatalf("failed to marshal expected public key: %v", err)
+	}
+	gotBytes, err := marshalUncompressedP256PublicKey(issuerPubKey)
+	if err != nil {
+		t.Fatalf("failed to marshal recovered public key: %v", err)
+	}
+	if string(gotBytes) != string(wantBytes) {
+		t.Fatalf("recovered public key does not match issuer key")
+	}
+}
+
+func TestVerifyVAPIDHeaderRejectsWrongAudience(t *testing.T) {
+	issuerKey, err := GenerateIssuerKeys()
+	if err != nil {
+		t.Fatalf("GenerateIssuerKeys returned an error: %v", err)
+	}
+
+	header, err := VAPIDHeader(issuerKey, "https://wallet-provider.example.com", "did:example:issuer")
+	if err != nil {
+		t.Fatalf("VAPIDHeader returned an error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "https://wallet-provider.example.com/generate/pub-key", nil)
+	req.Header.Set("Authorization", header)
+
+	if _, err := VerifyVAPIDHeader(req, "https://someone-else.example.com"); err == nil {
+		t.Fatalf("expected VerifyVAPIDHeader to reject an unexpected audience")
+	}
+}
+
+func TestVerifyVAPIDHeaderRejectsMissingAuthorization(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "https://wallet-provider.example.com/generate/pub-key", nil)
+
+	if _, err := VerifyVAPIDHeader(req, "https://wallet-provider.example.com"); err == nil {
+		t.Fatalf("expected VerifyVAPIDHeader to reject a missing Authorization header")
+	}
+}
+
+func TestVerifyVAPIDHeaderRejectsTamperedSignature(t *testing.T) {
+	issuerKey, err := GenerateIssuerKeys()
+	if err != nil {
+		t.Fatalf("GenerateIssuerKeys returned an error: %v", err)
+	}
+	otherKey, err := GenerateIssuerKeys()
+	if err != nil {
+		t.Fatalf("GenerateIssuerKeys returned an error: %v", err)
+	}
+
+	header, err := VAPIDHeader(issuerKey, "https://wallet-provider.example.com", "did:example:issuer")
+	if err != nil {
+		t.Fatalf("VAPIDHeader returned an error: %v", err)
+	}
+
+	token, _, err := parseVAPIDAuthorization(header)
+	if err != nil {
+		t.Fatalf("parseVAPIDAuthorization returned an error: %v", err)
+	}
+
+	otherPubKey, err := otherKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive unrelated public key: %v", err)
+	}
+	otherPubKeyBytes, err := marshalUncompressedP256PublicKey(otherPubKey)
+	if err != nil {
+		t.Fatalf("failed to marshal unrelated public key: %v", err)
+	}
+
+	tamperedHeader := "vapid t=" + token + ", k=" + base64.RawURLEncoding.EncodeToString(otherPubKeyBytes)
+
+	req := httptest.NewRequest(http.MethodPost, "https://wallet-provider.example.com/generate/pub-key", nil)
+	req.Header.Set("Authorization", tamperedHeader)
+
+	if _, err := VerifyVAPIDHeader(req, "https://wallet-provider.example.com"); err == nil {
+		t.Fatalf("expected VerifyVAPIDHeader to reject a public key that didn't sign the JWT")
+	}
+}
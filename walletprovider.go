@@ -0,0 +1,150 @@
+package cvc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+)
+
+// WalletProviderClient abstracts how IssuerConfig reaches a wallet
+// provider, so the transport - a REST call today, gRPC or an in-process
+// test double tomorrow - can vary independently of the issuer-side
+// protocol and so callers can cancel or time-bound the RPC via ctx. Future
+// wallet-provider operations (e.g. DeriveSharedSecret) land here as
+// transport needs grow; today there's a single RPC.
+type WalletProviderClient interface {
+	// GeneratePublicKeys asks the wallet provider to derive a public key
+	// for each of hashes, returning a map keyed by the same hash. alg
+	// tells the wallet provider which curve/algorithm to derive on, so
+	// both sides agree up front instead of the issuer discovering a
+	// mismatch only once it tries to combine keys in AddPublicKeys.
+	GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error)
+}
+
+// generatePublicKeysRequest is the wire format GeneratePublicKeys sends:
+// the hashes to derive keys for, plus the Algorithm both sides negotiate
+// up front. ProviderConfig.GeneratePublicKeys also accepts a bare JSON
+// array of hashes with no Algorithm field, defaulting to AlgorithmP256,
+// for compatibility with callers that predate this negotiation.
+type generatePublicKeysRequest struct {
+	Hashes    []string  `json:"hashes"`
+	Algorithm Algorithm `json:"algorithm"`
+}
+
+// HTTPWalletProvider is a WalletProviderClient backed by the wallet
+// provider's REST API, the module's original (and still default)
+// transport. HTTPClient defaults to http.DefaultClient when nil, so
+// callers needing timeouts, TLS pinning, or mTLS can inject their own.
+type HTTPWalletProvider struct {
+	ProviderURL string
+	HTTPClient  *http.Client
+	// Authorization, if set, is called fresh for every request and its
+	// result set as the Authorization header - e.g. VAPIDHeader, for
+	// issuer-authenticated requests.
+	Authorization func() (string, error)
+}
+
+// NewHTTPWalletProvider returns an HTTPWalletProvider for providerURL
+// using http.DefaultClient.
+func NewHTTPWalletProvider(providerURL string) *HTTPWalletProvider {
+	return &HTTPWalletProvider{ProviderURL: providerURL}
+}
+
+func (p *HTTPWalletProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// GeneratePublicKeys implements WalletProviderClient over the wallet
+// provider's POST /generate/pub-key endpoint.
+func (p *HTTPWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	requestBytes, err := json.Marshal(generatePublicKeysRequest{Hashes: hashes, Algorithm: alg})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal hashes: %w", err)
+	}
+
+	url := p.ProviderURL + path.Join("/", "generate", "pub-key")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(requestBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if p.Authorization != nil {
+		authHeader, err := p.Authorization()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build authorization header: %w", err)
+		}
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get response from wp: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("non-OK HTTP status: %d. Body: %s", resp.StatusCode, string(body))
+	}
+
+	var receivedMap map[string]KeyData
+	if err := json.Unmarshal(body, &receivedMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return receivedMap, nil
+}
+
+var _ WalletProviderClient = (*HTTPWalletProvider)(nil)
+
+// InProcessWalletProvider is a WalletProviderClient that calls a
+// generation function directly in-process, with no network hop at all -
+// for unit tests, and for deployments where the wallet provider lives in
+// the same process as the issuer.
+type InProcessWalletProvider struct {
+	// Generate computes the KeyData for a single hash on alg. It is
+	// called once per entry in GeneratePublicKeys' hashes argument.
+	Generate func(ctx context.Context, hash string, alg Algorithm) (KeyData, error)
+}
+
+func (p *InProcessWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	result := make(map[string]KeyData, len(hashes))
+	for _, hash := range hashes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data, err := p.Generate(ctx, hash, alg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate public key for hash %s: %w", hash, err)
+		}
+		result[hash] = data
+	}
+	return result, nil
+}
+
+var _ WalletProviderClient = (*InProcessWalletProvider)(nil)
+
+// backoffWithJitter returns a delay for retry attempt n (0-indexed):
+// base*2^n, capped at max, with up to 50% jitter subtracted so a burst of
+// callers retrying together don't all wake up in lockstep.
+func backoffWithJitter(base, max time.Duration, n int, jitter func() float64) time.Duration {
+	delay := base << n
+	if delay <= 0 || delay > max {
+		delay = max
+	}
+	return delay - time.Duration(jitter()*0.5*float64(delay))
+}
@@ -0,0 +1,52 @@
+package cvc
+
+import (
+	"context"
+	"fmt"
+)
+
+// GRPCWalletProviderStub is the subset of a generated gRPC client stub
+// GRPCWalletProvider needs. It's defined here rather than pulled in from
+// generated protobuf code so this module doesn't force a grpc-go/protobuf
+// dependency on callers who only use HTTPWalletProvider or
+// InProcessWalletProvider; a deployment wires its own generated stub
+// (matching this shape) in.
+type GRPCWalletProviderStub interface {
+	GeneratePublicKeys(ctx context.Context, in *GeneratePublicKeysRequest) (*GeneratePublicKeysResponse, error)
+}
+
+// GeneratePublicKeysRequest is the gRPC request message for
+// GRPCWalletProviderStub.GeneratePublicKeys.
+type GeneratePublicKeysRequest struct {
+	Hashes    []string
+	Algorithm Algorithm
+}
+
+// GeneratePublicKeysResponse is the gRPC response message for
+// GRPCWalletProviderStub.GeneratePublicKeys: KeyData keyed by the request
+// hash it was generated for, mirroring HTTPWalletProvider's JSON shape.
+type GeneratePublicKeysResponse struct {
+	Keys map[string]KeyData
+}
+
+// GRPCWalletProvider is a WalletProviderClient backed by a gRPC stub,
+// for deployments that run the wallet provider as a separate service
+// reached over gRPC instead of REST.
+type GRPCWalletProvider struct {
+	Stub GRPCWalletProviderStub
+}
+
+// NewGRPCWalletProvider wraps stub as a WalletProviderClient.
+func NewGRPCWalletProvider(stub GRPCWalletProviderStub) *GRPCWalletProvider {
+	return &GRPCWalletProvider{Stub: stub}
+}
+
+func (p *GRPCWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	resp, err := p.Stub.GeneratePublicKeys(ctx, &GeneratePublicKeysRequest{Hashes: hashes, Algorithm: alg})
+	if err != nil {
+		return nil, fmt.Errorf("wallet provider: gRPC GeneratePublicKeys failed: %w", err)
+	}
+	return resp.Keys, nil
+}
+
+var _ WalletProviderClient = (*GRPCWalletProvider)(nil)
@@ -0,0 +1,177 @@
+package cvc
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RetryWalletProvider wraps a WalletProviderClient with jittered
+// exponential backoff: on error, it retries up to MaxAttempts times total,
+// sleeping BaseDelay*2^n (capped at MaxDelay, jittered) between attempts,
+// and gives up early if ctx is done.
+type RetryWalletProvider struct {
+	Client      WalletProviderClient
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NewRetryWalletProvider wraps client with the given retry budget.
+func NewRetryWalletProvider(client WalletProviderClient, maxAttempts int, baseDelay, maxDelay time.Duration) *RetryWalletProvider {
+	return &RetryWalletProvider{Client: client, MaxAttempts: maxAttempts, BaseDelay: baseDelay, MaxDelay: maxDelay}
+}
+
+func (r *RetryWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	attempts := r.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			delay := backoffWithJitter(r.BaseDelay, r.MaxDelay, attempt-1, rand.Float64)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		result, err := r.Client.GeneratePublicKeys(ctx, hashes, alg)
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+	}
+
+	return nil, fmt.Errorf("wallet provider: all %d attempts failed, last error: %w", attempts, lastErr)
+}
+
+var _ WalletProviderClient = (*RetryWalletProvider)(nil)
+
+// circuitState is a CircuitBreakerWalletProvider's current state, modeled
+// on the standard closed/open/half-open breaker pattern.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreakerWalletProvider wraps a WalletProviderClient and stops
+// calling it once FailureThreshold consecutive failures have been seen,
+// failing fast for ResetTimeout before allowing a single half-open probe
+// call through to test recovery.
+type CircuitBreakerWalletProvider struct {
+	Client           WalletProviderClient
+	FailureThreshold int
+	ResetTimeout     time.Duration
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreakerWalletProvider wraps client, tripping open after
+// failureThreshold consecutive failures and staying open for resetTimeout.
+func NewCircuitBreakerWalletProvider(client WalletProviderClient, failureThreshold int, resetTimeout time.Duration) *CircuitBreakerWalletProvider {
+	return &CircuitBreakerWalletProvider{Client: client, FailureThreshold: failureThreshold, ResetTimeout: resetTimeout}
+}
+
+func (b *CircuitBreakerWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	if !b.allow() {
+		return nil, fmt.Errorf("wallet provider: circuit breaker open")
+	}
+
+	result, err := b.Client.GeneratePublicKeys(ctx, hashes, alg)
+	b.recordResult(err == nil)
+	return result, err
+}
+
+func (b *CircuitBreakerWalletProvider) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if time.Since(b.openedAt) < b.ResetTimeout {
+			return false
+		}
+		// Exactly one caller observes the timeout having elapsed while
+		// holding mu, transitions the breaker to half-open, and is
+		// admitted as its probe. Everyone else sees circuitHalfOpen below
+		// and is refused until recordResult resolves it, so a burst of
+		// concurrent callers after ResetTimeout can't all reach the
+		// client at once.
+		b.state = circuitHalfOpen
+		return true
+	case circuitHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreakerWalletProvider) recordResult(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if success {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.FailureThreshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+var _ WalletProviderClient = (*CircuitBreakerWalletProvider)(nil)
+
+// SigningWalletProvider wraps a WalletProviderClient and authenticates
+// each request to the wallet provider by signing the hashes payload with
+// Sign, attaching the result via ctx under signedRequestContextKey so the
+// underlying transport (e.g. HTTPWalletProvider, via a custom HTTPClient
+// or RoundTripper) can surface it as a request header or gRPC metadata
+// entry.
+type SigningWalletProvider struct {
+	Client WalletProviderClient
+	Sign   func(payload []byte) ([]byte, error)
+}
+
+// NewSigningWalletProvider wraps client, signing each request's hashes
+// payload with sign before it's dispatched.
+func NewSigningWalletProvider(client WalletProviderClient, sign func(payload []byte) ([]byte, error)) *SigningWalletProvider {
+	return &SigningWalletProvider{Client: client, Sign: sign}
+}
+
+type signedRequestContextKey struct{}
+
+// SignatureFromContext returns the request signature SigningWalletProvider
+// attached to ctx, if any.
+func SignatureFromContext(ctx context.Context) ([]byte, bool) {
+	sig, ok := ctx.Value(signedRequestContextKey{}).([]byte)
+	return sig, ok
+}
+
+func (s *SigningWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	payload := []byte(fmt.Sprintf("%v|%s", hashes, alg))
+	signature, err := s.Sign(payload)
+	if err != nil {
+		return nil, fmt.Errorf("wallet provider: failed to sign request: %w", err)
+	}
+
+	ctx = context.WithValue(ctx, signedRequestContextKey{}, signature)
+	return s.Client.GeneratePublicKeys(ctx, hashes, alg)
+}
+
+var _ WalletProviderClient = (*SigningWalletProvider)(nil)
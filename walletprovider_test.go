@@ -0,0 +1,146 @@
+package cvc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInProcessWalletProviderGeneratesPerHash(t *testing.T) {
+	provider := &InProcessWalletProvider{
+		Generate: func(ctx context.Context, hash string, alg Algorithm) (KeyData, error) {
+			return KeyData{KeyID: "key-" + hash}, nil
+		},
+	}
+
+	result, err := provider.GeneratePublicKeys(context.Background(), []string{"a", "b"}, AlgorithmP256)
+	if err != nil {
+		t.Fatalf("GeneratePublicKeys returned an error: %v", err)
+	}
+	if len(result) != 2 || result["a"].KeyID != "key-a" || result["b"].KeyID != "key-b" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestInProcessWalletProviderHonorsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	provider := &InProcessWalletProvider{
+		Generate: func(ctx context.Context, hash string, alg Algorithm) (KeyData, error) {
+			t.Fatalf("Generate should not be called once ctx is already cancelled")
+			return KeyData{}, nil
+		},
+	}
+
+	if _, err := provider.GeneratePublicKeys(ctx, []string{"a"}, AlgorithmP256); err == nil {
+		t.Fatalf("expected GeneratePublicKeys to return ctx's cancellation error")
+	}
+}
+
+type countingWalletProvider struct {
+	calls     int
+	failUntil int
+}
+
+func (c *countingWalletProvider) GeneratePublicKeys(ctx context.Context, hashes []string, alg Algorithm) (map[string]KeyData, error) {
+	c.calls++
+	if c.calls <= c.failUntil {
+		return nil, errors.New("transient failure")
+	}
+	return map[string]KeyData{}, nil
+}
+
+func TestRetryWalletProviderRetriesUntilSuccess(t *testing.T) {
+	inner := &countingWalletProvider{failUntil: 2}
+	retry := NewRetryWalletProvider(inner, 3, time.Millisecond, 5*time.Millisecond)
+
+	if _, err := retry.GeneratePublicKeys(context.Background(), nil, AlgorithmP256); err != nil {
+		t.Fatalf("GeneratePublicKeys returned an error: %v", err)
+	}
+	if inner.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", inner.calls)
+	}
+}
+
+func TestRetryWalletProviderGivesUpAfterMaxAttempts(t *testing.T) {
+	inner := &countingWalletProvider{failUntil: 10}
+	retry := NewRetryWalletProvider(inner, 3, time.Millisecond, 5*time.Millisecond)
+
+	if _, err := retry.GeneratePublicKeys(context.Background(), nil, AlgorithmP256); err == nil {
+		t.Fatalf("expected GeneratePublicKeys to return an error after exhausting retries")
+	}
+	if inner.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", inner.calls)
+	}
+}
+
+func TestCircuitBreakerWalletProviderOpensAfterThreshold(t *testing.T) {
+	inner := &countingWalletProvider{failUntil: 100}
+	breaker := NewCircuitBreakerWalletProvider(inner, 2, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := breaker.GeneratePublicKeys(context.Background(), nil, AlgorithmP256); err == nil {
+			t.Fatalf("expected failure on attempt %d", i)
+		}
+	}
+
+	callsBeforeOpen := inner.calls
+	if _, err := breaker.GeneratePublicKeys(context.Background(), nil, AlgorithmP256); err == nil {
+		t.Fatalf("expected circuit breaker to report an error once open")
+	}
+	if inner.calls != callsBeforeOpen {
+		t.Fatalf("expected the open breaker to short-circuit without calling the client, got %d calls", inner.calls)
+	}
+}
+
+func TestCircuitBreakerWalletProviderAdmitsOnlyOneHalfOpenProbe(t *testing.T) {
+	breaker := NewCircuitBreakerWalletProvider(&countingWalletProvider{}, 1, time.Hour)
+	breaker.mu.Lock()
+	breaker.state = circuitOpen
+	breaker.openedAt = time.Now().Add(-2 * time.Hour) // well past ResetTimeout
+	breaker.mu.Unlock()
+
+	const callers = 50
+	var admitted int32
+	var wg sync.WaitGroup
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if breaker.allow() {
+				atomic.AddInt32(&admitted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if admitted != 1 {
+		t.Fatalf("expected exactly 1 caller to be admitted as the half-open probe, got %d", admitted)
+	}
+}
+
+func TestSigningWalletProviderAttachesSignature(t *testing.T) {
+	var observedSig []byte
+	inner := &InProcessWalletProvider{
+		Generate: func(ctx context.Context, hash string, alg Algorithm) (KeyData, error) {
+			sig, _ := SignatureFromContext(ctx)
+			observedSig = sig
+			return KeyData{}, nil
+		},
+	}
+
+	signing := NewSigningWalletProvider(inner, func(payload []byte) ([]byte, error) {
+		return []byte("signature-for-" + string(payload)), nil
+	})
+
+	if _, err := signing.GeneratePublicKeys(context.Background(), []string{"a"}, AlgorithmP256); err != nil {
+		t.Fatalf("GeneratePublicKeys returned an error: %v", err)
+	}
+	if len(observedSig) == 0 {
+		t.Fatalf("expected the inner client to observe a signature in its context")
+	}
+}
@@ -0,0 +1,283 @@
+package cvc
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"filippo.io/edwards25519"
+	"github.com/MyNextID/cvc-go/internal"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// Curve selects the group Config's F0/F1 pipeline performs key agreement
+// and confirmation-key arithmetic over. CurveP256 (the zero value) keeps
+// this module's original behavior: ECDSA keys combined via
+// CipherServiceProvider.AddPublicKeys/AddSecretKeys. CurveX25519 routes
+// F0/F1 through the parallel Curve25519 arithmetic in this file instead.
+type Curve int
+
+const (
+	CurveP256 Curve = iota
+	CurveX25519
+)
+
+// x25519B64 is the base64url encoding used for the "x"/"d" members of an
+// OKP X25519 JWK.
+var x25519B64 = base64.RawURLEncoding
+
+// x25519JWK is the on-the-wire shape this file reads and writes for an OKP
+// X25519 key; D is omitted for public keys.
+//
+// The "x" member here is the raw Edwards25519 point encoding
+// (edwards25519.Point.Bytes()), not the RFC 8037 Montgomery u-coordinate a
+// third-party X25519 implementation would expect on the wire. Every key
+// this pipeline combines is generated by this same file, so round-tripping
+// through our own encoding is all F0/F1 need; converting to the standard
+// Montgomery encoding for an external peer is a separate concern this
+// request doesn't cover.
+type x25519JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	D   string `json:"d,omitempty"`
+}
+
+// GenerateX25519SecretKey generates a fresh, unclamped Curve25519 scalar
+// and its basepoint-multiple public point, and returns them as an OKP
+// X25519 jwk.Key.
+//
+// The scalar is deliberately left unclamped: RFC 7748 clamping only
+// matters at the Diffie-Hellman step, and clamping the stored scalar here
+// would break the additive homomorphism AddX25519SecretKeys and
+// AddX25519PublicKeys depend on - (s1 + s2 mod L)*G must equal
+// s1*G + s2*G, where L = 2^252 + 27742317777372353535851937790883648493
+// is Curve25519's prime subgroup order (RFC 8032 §5.1).
+func GenerateX25519SecretKey() (jwk.Key, error) {
+	var uniform [64]byte
+	if _, err := rand.Read(uniform[:]); err != nil {
+		return nil, internal.WrapError(internal.ErrKeyGeneration, "failed to generate Curve25519 scalar")
+	}
+
+	scalar, err := edwards25519.NewScalar().SetUniformBytes(uniform[:])
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrKeyGeneration, "failed to reduce Curve25519 scalar mod L")
+	}
+
+	return x25519JWKFromScalar(scalar)
+}
+
+// DeriveX25519SecretKey derives a child Curve25519 scalar from master key
+// material, the X25519 analogue of the CipherServiceProvider's
+// DeriveSecretKey for the Weierstrass curves: context and dst are expanded
+// via SHA-512 and reduced modulo L instead of a short Weierstrass curve
+// order.
+func DeriveX25519SecretKey(master jwk.Key, context, dst []byte) (jwk.Key, error) {
+	if master == nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, "master key cannot be nil")
+	}
+	if err := internal.ValidateNonEmpty(dst, "domain separation tag"); err != nil {
+		return nil, err
+	}
+
+	masterBytes, err := json.Marshal(master)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrJWKExtraction, "failed to convert master key to JSON")
+	}
+
+	h := sha512.New()
+	h.Write(dst)
+	h.Write(masterBytes)
+	h.Write(context)
+	uniform := h.Sum(nil)
+
+	scalar, err := edwards25519.NewScalar().SetUniformBytes(uniform)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrKeyDerivation, "failed to reduce derived Curve25519 scalar mod L")
+	}
+
+	return x25519JWKFromScalar(scalar)
+}
+
+// AddX25519SecretKeys adds two Curve25519 scalars modulo the group order L,
+// the X25519 analogue of AddSecretKeys.
+func AddX25519SecretKeys(key1, key2 jwk.Key) (jwk.Key, error) {
+	scalar1, err := x25519ScalarFromJWK(key1, "first key")
+	if err != nil {
+		return nil, err
+	}
+	scalar2, err := x25519ScalarFromJWK(key2, "second key")
+	if err != nil {
+		return nil, err
+	}
+
+	sum := edwards25519.NewScalar().Add(scalar1, scalar2)
+	if sum.Equal(edwards25519.NewScalar()) == 1 {
+		return nil, internal.WrapError(internal.ErrZeroScalar, "result scalar is zero (invalid private key)")
+	}
+
+	return x25519JWKFromScalar(sum)
+}
+
+// AddX25519PublicKeys adds two Curve25519 public points, the X25519
+// analogue of AddPublicKeys. Both points must be honest basepoint
+// multiples generated by this file - that's what makes
+// AddX25519SecretKeys' scalar addition correspond to this point addition.
+func AddX25519PublicKeys(key1, key2 jwk.Key) (jwk.Key, error) {
+	point1, err := x25519PointFromJWK(key1, "first key")
+	if err != nil {
+		return nil, err
+	}
+	point2, err := x25519PointFromJWK(key2, "second key")
+	if err != nil {
+		return nil, err
+	}
+
+	sum := edwards25519.NewIdentityPoint().Add(point1, point2)
+
+	return x25519PublicJWKFromPoint(sum)
+}
+
+func x25519JWKFromScalar(scalar *edwards25519.Scalar) (jwk.Key, error) {
+	point := edwards25519.NewIdentityPoint().ScalarBaseMult(scalar)
+	return parseX25519JWK(x25519JWK{
+		Kty: "OKP",
+		Crv: "X25519",
+		X:   x25519B64.EncodeToString(point.Bytes()),
+		D:   x25519B64.EncodeToString(scalar.Bytes()),
+	})
+}
+
+func x25519PublicJWKFromPoint(point *edwards25519.Point) (jwk.Key, error) {
+	return parseX25519JWK(x25519JWK{
+		Kty: "OKP",
+		Crv: "X25519",
+		X:   x25519B64.EncodeToString(point.Bytes()),
+	})
+}
+
+func parseX25519JWK(wire x25519JWK) (jwk.Key, error) {
+	data, err := json.Marshal(wire)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrJWKCreation, "failed to marshal X25519 JWK")
+	}
+	key, err := jwk.ParseKey(data)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrJWKCreation, "failed to parse X25519 JWK")
+	}
+	return key, nil
+}
+
+func x25519WireFromJWK(key jwk.Key, keyName string) (x25519JWK, error) {
+	var wire x25519JWK
+	if key == nil {
+		return wire, internal.WrapError(internal.ErrInvalidKey, fmt.Sprintf("%s cannot be nil", keyName))
+	}
+
+	raw, err := json.Marshal(key)
+	if err != nil {
+		return wire, internal.WrapError(internal.ErrJWKExtraction, fmt.Sprintf("failed to marshal %s", keyName))
+	}
+	if err := json.Unmarshal(raw, &wire); err != nil {
+		return wire, internal.WrapError(internal.ErrJWKExtraction, fmt.Sprintf("failed to unmarshal %s", keyName))
+	}
+	if wire.Crv != "X25519" {
+		return wire, internal.WrapError(internal.ErrKeyTypeUnsupported, fmt.Sprintf("%s is not an X25519 key", keyName))
+	}
+
+	return wire, nil
+}
+
+// x25519ScalarFromJWK extracts the private scalar from an OKP X25519
+// jwk.Key directly from its "d" member, rather than via crypto/ecdh -
+// ecdh.PrivateKey deliberately doesn't expose its raw scalar, but this
+// file needs the scalar itself to add two keys together.
+func x25519ScalarFromJWK(key jwk.Key, keyName string) (*edwards25519.Scalar, error) {
+	wire, err := x25519WireFromJWK(key, keyName)
+	if err != nil {
+		return nil, err
+	}
+	if wire.D == "" {
+		return nil, internal.WrapError(internal.ErrKeyTypeUnsupported, fmt.Sprintf("%s is not an X25519 private key", keyName))
+	}
+
+	dBytes, err := x25519B64.DecodeString(wire.D)
+	if err != nil || len(dBytes) != 32 {
+		return nil, internal.WrapError(internal.ErrInvalidKeyLength, fmt.Sprintf("%s has an invalid scalar encoding", keyName))
+	}
+
+	scalar, err := edwards25519.NewScalar().SetCanonicalBytes(dBytes)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrInvalidKey, fmt.Sprintf("%s scalar is not canonically reduced mod L", keyName))
+	}
+
+	return scalar, nil
+}
+
+func x25519PointFromJWK(key jwk.Key, keyName string) (*edwards25519.Point, error) {
+	wire, err := x25519WireFromJWK(key, keyName)
+	if err != nil {
+		return nil, err
+	}
+	if wire.X == "" {
+		return nil, internal.WrapError(internal.ErrInvalidKey, fmt.Sprintf("%s has no public point", keyName))
+	}
+
+	xBytes, err := x25519B64.DecodeString(wire.X)
+	if err != nil || len(xBytes) != 32 {
+		return nil, internal.WrapError(internal.ErrInvalidKeyLength, fmt.Sprintf("%s has an invalid public key encoding", keyName))
+	}
+
+	point, err := edwards25519.NewIdentityPoint().SetBytes(xBytes)
+	if err != nil {
+		return nil, internal.WrapError(internal.ErrKeyNotOnCurve, fmt.Sprintf("%s is not a valid Curve25519 point", keyName))
+	}
+
+	if point.Equal(edwards25519.NewIdentityPoint()) == 1 {
+		return nil, internal.WrapError(internal.ErrKeyAtInfinity, fmt.Sprintf("%s is the identity point", keyName))
+	}
+	if err := x25519CheckPrimeOrderSubgroup(point, keyName); err != nil {
+		return nil, err
+	}
+
+	return point, nil
+}
+
+// x25519SubgroupOrderMinusOne is L-1 as a canonical little-endian scalar,
+// where L = 2^252 + 27742317777372353535851937790883648493 is Curve25519's
+// prime subgroup order (RFC 8032 §5.1). edwards25519.Scalar only accepts
+// canonical values in [0, L), so L itself can't be represented directly;
+// x25519CheckPrimeOrderSubgroup computes L*P as (L-1)*P + P instead.
+var x25519SubgroupOrderMinusOne = [32]byte{
+	0xec, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58,
+	0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+}
+
+// x25519CheckPrimeOrderSubgroup rejects a point that isn't in Curve25519's
+// prime-order subgroup. Curve25519's cofactor is 8 (unlike every other
+// curve this module supports), so an untrusted "x" coordinate can decode
+// to a point with a component in the order-8 torsion subgroup; feeding
+// that into AddX25519PublicKeys would let an attacker confine a peer's
+// combined secret scalar to a handful of possible values - a classic
+// small-subgroup / invalid-curve-point attack, and exactly what
+// functions.go's F1 doc comment claims this path already defends against.
+// A point P is in the subgroup iff L*P is the identity; P's order divides
+// L iff it has no order-8 component, since gcd(L, 8) = 1.
+func x25519CheckPrimeOrderSubgroup(point *edwards25519.Point, keyName string) error {
+	lMinusOne, err := edwards25519.NewScalar().SetCanonicalBytes(x25519SubgroupOrderMinusOne[:])
+	if err != nil {
+		return internal.WrapError(internal.ErrInvalidKey, "failed to construct subgroup order scalar")
+	}
+
+	timesL := edwards25519.NewIdentityPoint().ScalarMult(lMinusOne, point)
+	timesL.Add(timesL, point) // (L-1)*P + P = L*P
+
+	if timesL.Equal(edwards25519.NewIdentityPoint()) != 1 {
+		return internal.WrapError(internal.ErrKeyNotOnCurve, fmt.Sprintf("%s has a small-subgroup component", keyName))
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package cvc
+
+import (
+	"testing"
+
+	"filippo.io/edwards25519"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// x25519OrderTwoPointB64 is the compressed encoding of (0, -1), the unique
+// point of order 2 on Curve25519's Edwards curve - one of the 8 points in
+// the order-8 torsion subgroup. It's a valid curve point (so SetBytes
+// accepts it) but must still be rejected: combining it via
+// AddX25519PublicKeys would confine the result to a handful of possible
+// values.
+const x25519OrderTwoPointB64 = "7P_______________________________________38"
+
+func TestX25519AddSecretKeysMatchesAddPublicKeys(t *testing.T) {
+	key1, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate first key: %v", err)
+	}
+	key2, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate second key: %v", err)
+	}
+
+	pub1, err := key1.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive first public key: %v", err)
+	}
+	pub2, err := key2.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive second public key: %v", err)
+	}
+
+	sumSecret, err := AddX25519SecretKeys(key1, key2)
+	if err != nil {
+		t.Fatalf("AddX25519SecretKeys failed: %v", err)
+	}
+	sumPublicFromSecret, err := sumSecret.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive public key from summed secret: %v", err)
+	}
+
+	sumPublic, err := AddX25519PublicKeys(pub1, pub2)
+	if err != nil {
+		t.Fatalf("AddX25519PublicKeys failed: %v", err)
+	}
+
+	gotWire, err := x25519WireFromJWK(sumPublicFromSecret, "summed secret's public key")
+	if err != nil {
+		t.Fatalf("failed to read summed secret's public key: %v", err)
+	}
+	wantWire, err := x25519WireFromJWK(sumPublic, "summed public key")
+	if err != nil {
+		t.Fatalf("failed to read summed public key: %v", err)
+	}
+
+	if gotWire.X != wantWire.X {
+		t.Fatalf("(s1+s2)*G = %s, want s1*G+s2*G = %s", gotWire.X, wantWire.X)
+	}
+}
+
+func TestX25519DeriveSecretKeyIsDeterministic(t *testing.T) {
+	master, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+
+	derived1, err := DeriveX25519SecretKey(master, []byte("context"), []byte("dst"))
+	if err != nil {
+		t.Fatalf("DeriveX25519SecretKey failed: %v", err)
+	}
+	derived2, err := DeriveX25519SecretKey(master, []byte("context"), []byte("dst"))
+	if err != nil {
+		t.Fatalf("DeriveX25519SecretKey failed: %v", err)
+	}
+
+	wire1, err := x25519WireFromJWK(derived1, "first derived key")
+	if err != nil {
+		t.Fatalf("failed to read first derived key: %v", err)
+	}
+	wire2, err := x25519WireFromJWK(derived2, "second derived key")
+	if err != nil {
+		t.Fatalf("failed to read second derived key: %v", err)
+	}
+
+	if wire1.D != wire2.D {
+		t.Fatalf("expected the same (master, context, dst) to derive the same scalar")
+	}
+}
+
+func TestX25519AddPublicKeysRejectsNonX25519Key(t *testing.T) {
+	p256Key, err := GenerateSecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate P-256 key: %v", err)
+	}
+	p256Pub, err := p256Key.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive P-256 public key: %v", err)
+	}
+
+	x25519Key, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate X25519 key: %v", err)
+	}
+	x25519Pub, err := x25519Key.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive X25519 public key: %v", err)
+	}
+
+	if _, err := AddX25519PublicKeys(p256Pub, x25519Pub); err == nil {
+		t.Fatalf("expected AddX25519PublicKeys to reject a non-X25519 key")
+	}
+}
+
+func TestConfigF0F1WithCurveX25519(t *testing.T) {
+	masterKey, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate master key: %v", err)
+	}
+	config := &Config{
+		MasterKeyStore: staticMasterKeyStore{key: masterKey},
+		CredentialKey:  []byte("credential-key"),
+		Curve:          CurveX25519,
+	}
+
+	userMap, err := config.F0(map[string]string{"user-1": "user-1@example.com"})
+	if err != nil {
+		t.Fatalf("F0 returned an error: %v", err)
+	}
+
+	vcPayload := map[string]interface{}{}
+	if err := config.F1("user-1", vcPayload, userMap); err != nil {
+		t.Fatalf("F1 returned an error: %v", err)
+	}
+
+	if userMap["user-1"].VcPubKey == nil {
+		t.Fatalf("expected F1 to populate VcPubKey")
+	}
+	if _, ok := vcPayload["cnf"]; !ok {
+		t.Fatalf("expected F1 to add a cnf claim to the VC payload")
+	}
+}
+
+func x25519KeyFromX(t *testing.T, xB64 string) jwk.Key {
+	t.Helper()
+	key, err := parseX25519JWK(x25519JWK{Kty: "OKP", Crv: "X25519", X: xB64})
+	if err != nil {
+		t.Fatalf("failed to build test key: %v", err)
+	}
+	return key
+}
+
+func TestX25519AddPublicKeysRejectsIdentityPoint(t *testing.T) {
+	identityB64 := x25519B64.EncodeToString(edwards25519.NewIdentityPoint().Bytes())
+	identityKey := x25519KeyFromX(t, identityB64)
+
+	honestKey, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate honest key: %v", err)
+	}
+	honestPub, err := honestKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive honest public key: %v", err)
+	}
+
+	if _, err := AddX25519PublicKeys(identityKey, honestPub); err == nil {
+		t.Fatalf("expected AddX25519PublicKeys to reject the identity point")
+	}
+}
+
+func TestX25519AddPublicKeysRejectsSmallSubgroupPoint(t *testing.T) {
+	orderTwoKey := x25519KeyFromX(t, x25519OrderTwoPointB64)
+
+	honestKey, err := GenerateX25519SecretKey()
+	if err != nil {
+		t.Fatalf("failed to generate honest key: %v", err)
+	}
+	honestPub, err := honestKey.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to derive honest public key: %v", err)
+	}
+
+	if _, err := AddX25519PublicKeys(orderTwoKey, honestPub); err == nil {
+		t.Fatalf("expected AddX25519PublicKeys to reject an order-2 small-subgroup point")
+	}
+	if _, err := AddX25519PublicKeys(honestPub, orderTwoKey); err == nil {
+		t.Fatalf("expected AddX25519PublicKeys to reject an order-2 small-subgroup point (reversed operands)")
+	}
+}